@@ -0,0 +1,48 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of "now" for everything time dependent
+type Clock interface {
+	Now() time.Time
+}
+
+// Real reads the system clock
+type Real struct{}
+
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// Manual is a clock that only moves when told to, used by tests and catch-up simulation
+type Manual struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewManual(start time.Time) *Manual {
+	return &Manual{now: start}
+}
+
+func (m *Manual) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now
+}
+
+// Advance moves the clock forward by d
+func (m *Manual) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = m.now.Add(d)
+}
+
+// Set jumps the clock to t
+func (m *Manual) Set(t time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = t
+}
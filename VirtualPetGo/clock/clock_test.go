@@ -0,0 +1,25 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestManualAdvance(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clk := NewManual(start)
+
+	if !clk.Now().Equal(start) {
+		t.Errorf("Expected %v, got %v", start, clk.Now())
+	}
+
+	clk.Advance(90 * time.Second)
+	if want := start.Add(90 * time.Second); !clk.Now().Equal(want) {
+		t.Errorf("Expected %v, got %v", want, clk.Now())
+	}
+
+	clk.Set(start)
+	if !clk.Now().Equal(start) {
+		t.Errorf("Expected %v after Set, got %v", start, clk.Now())
+	}
+}
@@ -0,0 +1,49 @@
+package game
+
+import (
+	"VirtualPetGo/pet"
+	"VirtualPetGo/save"
+	"sync"
+)
+
+// fakeUI records what the game shows instead of printing it
+type fakeUI struct {
+	mu       sync.Mutex
+	messages []string
+	alerts   []string
+}
+
+func (f *fakeUI) DisplayWelcome()                           {}
+func (f *fakeUI) DisplayMainMenu(pet.Pet)                   {}
+func (f *fakeUI) DisplayStatus(pet.Pet)                     {}
+func (f *fakeUI) ClearScreen()                              {}
+func (f *fakeUI) DisplayPetSelection([]pet.Descriptor)      {}
+func (f *fakeUI) DisplayBreedSelection(string, []pet.Breed) {}
+func (f *fakeUI) DisplayWarnings(pet.Pet)                   {}
+func (f *fakeUI) DisplaySlotManager([]save.SlotInfo)        {}
+func (f *fakeUI) DisplayOfflineReport(pet.OfflineReport)    {}
+func (f *fakeUI) DisplaySettings(float64)                   {}
+func (f *fakeUI) DisplayPantry(pet.Pet)                     {}
+func (f *fakeUI) DisplayShop(pet.Pet, []pet.ShopItem)       {}
+func (f *fakeUI) DisplayMiniGame(pet.Pet)                   {}
+func (f *fakeUI) DisplayMedicine(pet.Pet, []pet.ShopItem)   {}
+
+func (f *fakeUI) DisplayMessage(message string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.messages = append(f.messages, message)
+}
+
+func (f *fakeUI) DisplayAlert(message string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.alerts = append(f.alerts, message)
+}
+
+func (f *fakeUI) takeAlerts() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	alerts := f.alerts
+	f.alerts = nil
+	return alerts
+}
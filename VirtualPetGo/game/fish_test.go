@@ -0,0 +1,31 @@
+package game
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/pet"
+	"VirtualPetGo/save"
+	"testing"
+)
+
+func TestUnsupportedActionIsRefused(t *testing.T) {
+	ui := &fakeUI{}
+	clk := clock.NewManual(testStart)
+	gm := NewGameManager(ui, WithClock(clk), WithSeed(5), WithSaveDir(t.TempDir()))
+
+	gm.currentPet = pet.NewFish("Bubbles", gm.petOptions()...)
+	gm.slot = "Bubbles"
+	gm.openJournal()
+	gm.writeSave()
+
+	gm.handleAction(2, "") // Play
+	gm.closeJournal()
+
+	if len(ui.messages) != 1 || ui.messages[0] != "A Fish can't play!" {
+		t.Errorf("Expected Play to be refused, got %q", ui.messages)
+	}
+	entries, _ := save.ReadJournal(gm.slots.JournalPath("Bubbles"))
+	last := entries[len(entries)-1]
+	if last.Choice != 2 || last.Action != "" {
+		t.Errorf("A refused action should be recorded without an action, got %+v", last)
+	}
+}
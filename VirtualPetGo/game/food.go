@@ -0,0 +1,36 @@
+package game
+
+import (
+	"VirtualPetGo/pet"
+	"VirtualPetGo/utils"
+)
+
+// chooseFood shows the pantry and asks which food to feed, false when the
+// player backs out
+func (gm *GameManager) chooseFood() (string, bool) {
+	foods := pet.Foods()
+	choice, ok := gm.ask(len(foods), func() { gm.ui.DisplayPantry(gm.currentPet) })
+	if !ok || choice == 0 {
+		return "", ok
+	}
+	return foods[choice-1].Name, true
+}
+
+// ask shows a prompt and reads a choice from 0 to n without holding the lock,
+// 0 is going back and is reported as false. A paused pet isn't asked, the
+// choice is 0 and handleAction turns the action down.
+func (gm *GameManager) ask(n int, show func()) (int, bool) {
+	gm.lockMain()
+	if gm.currentPet.IsPaused() {
+		gm.mu.Unlock()
+		return 0, true
+	}
+	show()
+	gm.mu.Unlock()
+
+	choice, err := utils.ReadIntInRange(0, n)
+	if err != nil || choice == 0 {
+		return 0, false
+	}
+	return choice, true
+}
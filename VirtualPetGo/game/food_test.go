@@ -0,0 +1,49 @@
+package game
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/pet"
+	"VirtualPetGo/utils"
+	"io"
+	"strings"
+	"testing"
+)
+
+// withInput makes the game read the given lines, then the end of input
+func withInput(t *testing.T, input ...string) {
+	t.Helper()
+	readLine := utils.ReadLine
+	t.Cleanup(func() { utils.ReadLine = readLine })
+	utils.ReadLine = func() (string, error) {
+		if len(input) == 0 {
+			return "", io.EOF
+		}
+		line := input[0]
+		input = input[1:]
+		return line, nil
+	}
+}
+
+func TestFeedingUsesTheChosenFood(t *testing.T) {
+	ui := &fakeUI{}
+	gm := NewGameManager(ui, WithClock(clock.NewManual(testStart)), WithSeed(5), WithSaveDir(t.TempDir()))
+	gm.currentPet = pet.NewCat("Whiskers", "Orange", gm.petOptions()...)
+
+	withInput(t, "2", "0")
+	food, ok := gm.chooseFood()
+	if !ok || food != "Tuna" {
+		t.Fatalf("Expected Tuna, got %q (%v)", food, ok)
+	}
+	gm.handleAction(1, food)
+	if left := gm.currentPet.Pantry()["Tuna"]; left != 2 {
+		t.Errorf("Expected 2 tuna left, got %d", left)
+	}
+	if last := ui.messages[len(ui.messages)-1]; !strings.Contains(last, "loves Tuna") {
+		t.Errorf("Expected the cat to love its tuna, got %q", last)
+	}
+
+	// 0 goes back to the menu without feeding
+	if _, ok := gm.chooseFood(); ok {
+		t.Error("Choosing 0 should back out")
+	}
+}
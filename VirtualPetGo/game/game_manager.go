@@ -1,36 +1,99 @@
 package game
 
 import (
+	"VirtualPetGo/clock"
 	"VirtualPetGo/pet"
+	"VirtualPetGo/save"
+	"VirtualPetGo/session"
 	"VirtualPetGo/ui"
 	"VirtualPetGo/utils"
 	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
 	"time"
 )
 
 type GameManager struct {
+	// mu guards the pet, the background ticker updates it while the menu waits for input
+	mu sync.Mutex
+
 	currentPet     pet.Pet
 	lastUpdateTime time.Time
 	ui             ui.IUserInterface
+	slots          *save.Slots
+	slot           string // Name of the slot the current pet is saved in
+	maxOffline     time.Duration
+	clock          clock.Clock
+	seed           uint64
+	source         *rand.PCG // Behind rng, kept to checkpoint its state in the journal
+	rng            *rand.Rand
+	shownWarnings  map[string]bool
+	journal        *save.Journal // Of the current slot, nil when not recording
+	trustedEdit    bool          // The pet is from an edited save the override let in, see flagEditedSave
+
+	recorder *session.Recorder // Set when recording the session
+	player   *session.Player   // Set when replaying a recorded session
+
+	autosaveInterval time.Duration
+	timeScale        float64 // Applied to every pet created or loaded, see settings
+	trustEditedSaves bool    // Developer override, see WithTrustEditedSaves
 }
 
-func NewGameManager(userInterface ui.IUserInterface) *GameManager {
-	return &GameManager{
-		currentPet:     nil,
-		lastUpdateTime: time.Now(),
-		ui:             userInterface,
+func NewGameManager(userInterface ui.IUserInterface, opts ...Option) *GameManager {
+	gm := &GameManager{
+		currentPet: nil,
+		ui:         userInterface,
+		slots:      save.NewSlots(save.DefaultDir),
+		maxOffline: DefaultMaxOffline,
+		clock:      clock.Real{},
+		seed:       rand.Uint64(),
+
+		autosaveInterval: DefaultAutosave,
+		timeScale:        DefaultTimeScale,
 	}
+	for _, opt := range opts {
+		opt(gm)
+	}
+	gm.source = rand.NewPCG(gm.seed, gm.seed)
+	gm.rng = rand.New(gm.source)
+	if gm.recorder != nil {
+		gm.startRecording()
+	}
+	gm.lastUpdateTime = gm.clock.Now()
+	return gm
 }
 
 func (gm *GameManager) GetPet() pet.Pet {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
 	return gm.currentPet
 }
+
+// Seed returns the seed of the game's random source, quote it in bug reports
+func (gm *GameManager) Seed() uint64 {
+	return gm.seed
+}
 func (gm *GameManager) Start() {
 	// Display welcome screen
 	gm.ui.DisplayWelcome()
+	gm.ui.DisplayMessage(fmt.Sprintf("Game seed: %d", gm.seed))
 
-	// Create the pet
-	gm.createPet()
+	defer func() {
+		gm.mu.Lock()
+		gm.finishRecording(false)
+		gm.mu.Unlock()
+	}()
+
+	// Saves from before signing are trusted the first time this version runs
+	if err := gm.slots.SignLegacy(); err != nil {
+		gm.ui.DisplayMessage("Could not sign the old saves: " + err.Error())
+	}
+
+	// Pick a save slot, or create a new pet in one
+	if !gm.chooseSlot() {
+		return
+	}
 
 	// Run the main game loop
 	gm.gameLoop()
@@ -38,38 +101,69 @@ func (gm *GameManager) Start() {
 
 // createPet handles pet creation phase
 func (gm *GameManager) createPet() {
-	// Display pet selection menu
-	gm.ui.DisplayPetSelection()
+	// Display pet selection menu, every registered species in order
+	species := pet.Registered()
+	gm.ui.DisplayPetSelection(species)
 
 	// Get pet type choice
-	petType, _ := utils.ReadIntInRange(1, 3)
+	petType, err := utils.ReadIntInRange(1, len(species))
+	if err != nil {
+		return
+	}
+
+	// Get the breed, for species that have them
+	chosen := species[petType-1]
+	var breed string
+	if len(chosen.Breeds) > 0 {
+		gm.ui.DisplayBreedSelection(chosen.Name, chosen.Breeds)
+		choice, err := utils.ReadIntInRange(1, len(chosen.Breeds))
+		if err != nil {
+			return
+		}
+		breed = chosen.Breeds[choice-1].Name
+	}
 
 	// Get pet name
 	fmt.Print("\nEnter your pet's name: ")
-	name, _ := utils.ReadString()
-
-	// Get variant based on pet type
-	var variant string
-	switch petType {
-	case 1: // Dog
-		gm.currentPet = pet.NewDog(name)
-		fmt.Printf("\n%s the %s has been born!\n", name, variant)
-
-	case 2: // Cat
-		gm.currentPet = pet.NewCat(name)
-		fmt.Printf("\n%s the %s cat has been born!\n", name, variant)
+	name, err := utils.ReadString()
+	if err != nil {
+		return
+	}
 
-	case 3: // Bird
-		gm.currentPet = pet.NewBird(name)
-		fmt.Printf("\n%s the %s has been born!\n", name, variant)
+	// Every pet is born with a few personality traits
+	traits := pet.RollTraits(gm.rng)
+	born := chosen.New(name, breed, append(gm.petOptions(), pet.WithTraits(traits...))...)
+	if born == nil {
+		return
+	}
+	born.SetTimeScale(gm.timeScale)
+	if breed != "" {
+		fmt.Printf("\n%s the %s %s has been born!\n", name, breed, chosen.Name)
+	} else {
+		fmt.Printf("\n%s the %s has been born!\n", name, chosen.Name)
 	}
+	fmt.Printf("%s is %s.\n", name, strings.Join(traits, ", "))
+
+	// Hand the pet over, Shutdown may read it from here on
+	gm.mu.Lock()
+	gm.currentPet = born
+	gm.lastUpdateTime = gm.clock.Now()
+	gm.mu.Unlock()
+}
 
-	gm.lastUpdateTime = time.Now()
+// petOptions are the options every pet created or loaded by the game gets
+func (gm *GameManager) petOptions() []pet.Option {
+	return []pet.Option{pet.WithClock(gm.clock), pet.WithRand(gm.rng)}
 }
 
 // gameLoop is the main game loop
 func (gm *GameManager) gameLoop() {
+	// Keep the pet living while the menu waits for input
+	stopTicker := gm.startTicker()
+	defer stopTicker()
+
 	for {
+		gm.lockMain()
 		gm.ui.ClearScreen()
 		// Update pet stats based on elapsed time
 		gm.updatePet()
@@ -78,20 +172,50 @@ func (gm *GameManager) gameLoop() {
 		if !gm.currentPet.IsAlive() {
 			gm.ui.DisplayStatus(gm.currentPet)
 			fmt.Printf("\n %s has died... Game Over.\n", gm.currentPet.GetStatus().Name)
+			gm.savePet()
+			gm.closeJournal()
+			gm.mu.Unlock()
 			utils.WaitForEnter()
 			break
 		}
 		gm.ui.DisplayStatus(gm.currentPet)
 		// Display warnings if any stats are critical
 		gm.ui.DisplayWarnings(gm.currentPet)
+		gm.markWarningsShown()
 		// Display menu
-		gm.ui.DisplayMainMenu()
+		gm.ui.DisplayMainMenu(gm.currentPet)
+		gm.mu.Unlock()
 
-		// Get user choice (1-8)
-		choice, _ := utils.ReadIntInRange(1, 8)
+		// Get user choice (0-13), leave when the input has ended
+		choice, err := utils.ReadIntInRange(0, 13)
+		if err != nil {
+			choice = 9
+		}
+
+		// The settings screen asks for more input, so it handles the lock itself
+		if choice == 0 {
+			gm.settings()
+			utils.WaitForEnter()
+			continue
+		}
+
+		// Some actions ask for an item first, also without holding the lock
+		var item string
+		if choose, ok := gm.itemChoosers()[choice]; ok {
+			if item, ok = choose(); !ok {
+				continue
+			}
+		}
 
 		// Handle the action, returns false if user wants to exit
-		if !gm.handleAction(choice) {
+		gm.lockMain()
+		keepPlaying := gm.handleAction(choice, item)
+		if !keepPlaying {
+			gm.savePet()
+			gm.closeJournal()
+		}
+		gm.mu.Unlock()
+		if !keepPlaying {
 			fmt.Println("\nThanks for playing! Goodbye!")
 			break
 		}
@@ -101,54 +225,105 @@ func (gm *GameManager) gameLoop() {
 }
 
 // updatePet updates the pet's stats based on time elapsed
+// Callers must hold gm.mu
 func (gm *GameManager) updatePet() {
 	if gm.currentPet == nil {
 		return
 	}
 
-	now := time.Now()
+	now := gm.clock.Now()
 	deltaTime := now.Sub(gm.lastUpdateTime).Seconds()
 
+	before := gm.currentPet.GetStatus()
 	gm.currentPet.Update(deltaTime)
 	gm.lastUpdateTime = now
-}
 
-// handleAction processes user's menu choice
-// Returns false if user wants to exit, true otherwise
-func (gm *GameManager) handleAction(choice int) bool {
-	switch choice {
-	case 1: // Feed
-		result := gm.currentPet.Feed()
-		gm.ui.DisplayMessage(result)
+	if deltaTime > 0 {
+		gm.record(save.Entry{
+			At:      now,
+			Kind:    save.EntryUpdate,
+			Elapsed: deltaTime,
+			Delta:   save.StatDelta(before, gm.currentPet.GetStatus()),
+		})
+	}
 
-	case 2: // Play
-		result := gm.currentPet.Play()
-		gm.ui.DisplayMessage(result)
+	for _, event := range gm.currentPet.TakeEvents() {
+		gm.ui.DisplayAlert(event)
+	}
+}
 
-	case 3: // Sleep
-		result := gm.currentPet.Sleep()
-		gm.ui.DisplayMessage(result)
+// menuActions are the main menu choices that do something to the pet
+var menuActions = map[int]pet.Action{
+	1:  pet.ActionFeed,
+	2:  pet.ActionPlay,
+	3:  pet.ActionSleep,
+	4:  pet.ActionClean,
+	5:  pet.ActionInteract, // Make Sound
+	6:  pet.ActionAbility,
+	10: pet.ActionPause, // Resume when paused
+	11: pet.ActionBuy,
+	12: pet.ActionMiniGame,
+	13: pet.ActionMedicine,
+}
 
-	case 4: // Clean
-		result := gm.currentPet.Clean()
-		gm.ui.DisplayMessage(result)
+// itemChoosers ask for the item of the menu choices that need one
+func (gm *GameManager) itemChoosers() map[int]func() (string, bool) {
+	return map[int]func() (string, bool){
+		1:  gm.chooseFood,
+		11: gm.chooseShopItem,
+		12: gm.chooseCup,
+		13: gm.chooseMedicine,
+	}
+}
 
-	case 5: // Interact (Make Sound)
-		result := gm.currentPet.Interact()
-		gm.ui.DisplayMessage(result)
+// handleAction processes user's menu choice, every choice is recorded in the journal.
+// item is what the action is done with, see pet.PerformWith.
+// Returns false if user wants to exit, true otherwise
+// Callers must hold gm.mu
+func (gm *GameManager) handleAction(choice int, item string) bool {
+	now := gm.clock.Now()
+	before := gm.currentPet.GetStatus()
 
-	case 6: // Use Special Ability
-		if gm.currentPet.CanUseAbility() {
-			result := gm.currentPet.UseSpecialAbility()
-			gm.ui.DisplayMessage(result)
-		} else {
-			gm.ui.DisplayMessage("Special ability is not available right now!")
+	action := menuActions[choice]
+	// A paused pet can only be resumed
+	if gm.currentPet.IsPaused() {
+		if action == pet.ActionPause {
+			action = pet.ActionResume
+		} else if action != "" {
+			gm.ui.DisplayMessage(before.Name + " is paused, resume (10) to look after them.")
+			action = ""
 		}
+	}
+	// Some species can't do every action, the menu marks those
+	if action != "" && !gm.currentPet.Supports(action) {
+		gm.ui.DisplayMessage(pet.Perform(gm.currentPet, action))
+		action = ""
+	}
+	if action == "" {
+		item = ""
+	}
+	if action != "" {
+		result := pet.PerformWith(gm.currentPet, action, item)
+		gm.ui.DisplayMessage(result)
+	}
 
+	gm.record(save.Entry{
+		At:     now,
+		Kind:   save.EntryAction,
+		Choice: choice,
+		Action: action,
+		Item:   item,
+		Delta:  save.StatDelta(before, gm.currentPet.GetStatus()),
+	})
+
+	switch choice {
 	case 7: // View Status
 		gm.ui.DisplayStatus(gm.currentPet)
 
-	case 8: // Exit Game
+	case 8: // Save Game
+		gm.savePet()
+
+	case 9: // Exit Game
 		return false
 	}
 
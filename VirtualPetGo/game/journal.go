@@ -0,0 +1,74 @@
+package game
+
+import (
+	"VirtualPetGo/pet"
+	"VirtualPetGo/save"
+	"time"
+)
+
+// openJournal starts recording to the journal of the current slot
+// Callers must hold gm.mu
+func (gm *GameManager) openJournal() {
+	gm.closeJournal()
+
+	journal, err := gm.slots.OpenJournal(gm.slot)
+	if err != nil {
+		gm.ui.DisplayMessage("Could not open the journal, playing without it: " + err.Error())
+		return
+	}
+	gm.journal = journal
+}
+
+// closeJournal stops recording
+// Callers must hold gm.mu
+func (gm *GameManager) closeJournal() {
+	if gm.journal != nil {
+		gm.journal.Close()
+		gm.journal = nil
+	}
+}
+
+// record appends an entry to the journal. If the journal can't be written
+// it is reported once and turned off, the game goes on without it.
+// Callers must hold gm.mu
+func (gm *GameManager) record(entry save.Entry) {
+	if gm.journal == nil {
+		return
+	}
+	if err := gm.journal.Append(entry); err != nil {
+		gm.ui.DisplayAlert("Journal stopped: " + err.Error())
+		gm.closeJournal()
+	}
+}
+
+// checkpoint records the full pet and the random source, a replay can start from here.
+// Callers must hold gm.mu
+func (gm *GameManager) checkpoint(at time.Time, snapshot pet.Snapshot) {
+	if gm.journal == nil {
+		return
+	}
+	state, err := gm.source.MarshalBinary()
+	if err != nil {
+		gm.ui.DisplayAlert("Journal stopped: " + err.Error())
+		gm.closeJournal()
+		return
+	}
+	entry := save.Entry{
+		At:         at,
+		Kind:       save.EntryCheckpoint,
+		Checkpoint: &save.Checkpoint{Pet: snapshot, Rand: state},
+	}
+	gm.record(entry)
+}
+
+// compactJournal keeps the journal from growing for good, see Journal.Compact
+// Callers must hold gm.mu
+func (gm *GameManager) compactJournal() {
+	if gm.journal == nil {
+		return
+	}
+	if err := gm.journal.Compact(); err != nil {
+		gm.ui.DisplayAlert("Journal stopped: " + err.Error())
+		gm.closeJournal()
+	}
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/pet"
+	"VirtualPetGo/save"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func snapshotJSON(s pet.Snapshot) string {
+	s.BirthTime = s.BirthTime.UTC()
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+func TestJournalReplaysTheSession(t *testing.T) {
+	dir := t.TempDir()
+	clk := clock.NewManual(testStart)
+	gm := NewGameManager(&fakeUI{}, WithClock(clk), WithSeed(5), WithSaveDir(dir))
+
+	gm.currentPet = pet.NewBird("Tweety", "Canary", gm.petOptions()...)
+	gm.slot = "Tweety"
+	gm.openJournal()
+	if err := gm.writeSave(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	for i := 0; i < 30; i++ {
+		clk.Advance(1100 * time.Millisecond)
+		gm.tick()
+		if i%5 == 0 {
+			gm.handleAction(1+i/5, "Seeds") // Feed seeds through Use Special Ability
+		}
+	}
+	gm.handleAction(7, "") // Status screen, recorded but changes nothing
+
+	entries, err := save.ReadJournal(gm.slots.JournalPath("Tweety"))
+	if err != nil {
+		t.Fatalf("ReadJournal failed: %v", err)
+	}
+	file, err := save.Replay(entries)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if got, want := snapshotJSON(file.Pet), snapshotJSON(gm.currentPet.Snapshot()); got != want {
+		t.Errorf("Replay gave a different pet:\nwant %s\ngot  %s", want, got)
+	}
+
+	// Crash without saving, the next game recovers the pet from the journal
+	gm.closeJournal()
+	next := NewGameManager(&fakeUI{}, WithClock(clk), WithSeed(6), WithSaveDir(dir))
+	if !next.loadSlot("Tweety") {
+		t.Fatal("Slot should load")
+	}
+	if got, want := snapshotJSON(next.currentPet.Snapshot()), snapshotJSON(gm.currentPet.Snapshot()); got != want {
+		t.Errorf("Recovered pet differs:\nwant %s\ngot  %s", want, got)
+	}
+	next.closeJournal()
+
+	// Time away is caught up on load, the journal replays across all sessions
+	clk.Advance(90 * time.Second)
+	last := NewGameManager(&fakeUI{}, WithClock(clk), WithSeed(7), WithSaveDir(dir))
+	if !last.loadSlot("Tweety") {
+		t.Fatal("Slot should load")
+	}
+	clk.Advance(3 * time.Second)
+	last.tick()
+	last.closeJournal()
+
+	entries, _ = save.ReadJournal(gm.slots.JournalPath("Tweety"))
+	file, err = save.Replay(entries)
+	if err != nil {
+		t.Fatalf("Replay across sessions failed: %v", err)
+	}
+	if got, want := snapshotJSON(file.Pet), snapshotJSON(last.currentPet.Snapshot()); got != want {
+		t.Errorf("Replay across sessions gave a different pet:\nwant %s\ngot  %s", want, got)
+	}
+}
+
+func TestSavingKeepsTheJournal(t *testing.T) {
+	clk := clock.NewManual(testStart)
+	gm := NewGameManager(&fakeUI{}, WithClock(clk), WithSeed(5), WithSaveDir(t.TempDir()))
+	gm.currentPet = pet.NewDog("Max", "Golden Retriever", gm.petOptions()...)
+	gm.slot = "Max"
+	gm.openJournal()
+
+	for saves := 0; saves < 3; saves++ {
+		for i := 0; i < 10; i++ {
+			clk.Advance(time.Second)
+			gm.tick()
+		}
+		if err := gm.writeSave(); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	clk.Advance(time.Second)
+	gm.tick()
+	gm.closeJournal()
+
+	entries, err := save.ReadJournal(gm.slots.JournalPath("Max"))
+	if err != nil {
+		t.Fatalf("ReadJournal failed: %v", err)
+	}
+	// Saves add a checkpoint, the history before them stays
+	checkpoints := 0
+	for _, entry := range entries {
+		if entry.Kind == save.EntryCheckpoint {
+			checkpoints++
+		}
+	}
+	if len(entries) != 34 || checkpoints != 3 || !entries[0].At.Equal(testStart.Add(time.Second)) {
+		t.Fatalf("Expected every update and a checkpoint per save, got %d entries and %d checkpoints", len(entries), checkpoints)
+	}
+	if _, err := save.Replay(entries); err != nil {
+		t.Errorf("The journal should replay: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package game
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/pet"
+	"VirtualPetGo/save"
+	"VirtualPetGo/session"
+	"time"
+)
+
+// DefaultMaxOffline is how much time away is simulated when a saved pet is resumed
+const DefaultMaxOffline = 8 * time.Hour
+
+// DefaultAutosave is how often the pet is saved to its slot while playing
+const DefaultAutosave = 30 * time.Second
+
+// Option configures a GameManager
+type Option func(*GameManager)
+
+// WithMaxOffline caps the offline progression, 0 means no cap
+func WithMaxOffline(limit time.Duration) Option {
+	return func(gm *GameManager) {
+		gm.maxOffline = limit
+	}
+}
+
+// WithClock makes the game and its pets read time from c instead of the system clock
+func WithClock(c clock.Clock) Option {
+	return func(gm *GameManager) {
+		gm.clock = c
+	}
+}
+
+// WithSeed makes every random outcome in the game reproducible from seed
+func WithSeed(seed uint64) Option {
+	return func(gm *GameManager) {
+		gm.seed = seed
+	}
+}
+
+// WithSaveDir keeps the save slots in dir
+func WithSaveDir(dir string) Option {
+	return func(gm *GameManager) {
+		gm.slots = save.NewSlots(dir)
+	}
+}
+
+// WithAutosave saves the pet every interval while playing, 0 turns autosave off
+func WithAutosave(interval time.Duration) Option {
+	return func(gm *GameManager) {
+		gm.autosaveInterval = interval
+	}
+}
+
+// WithTimeScale runs pets scale times faster than real time, clamped to
+// pet.MinTimeScale..pet.MaxTimeScale. It can be changed in the settings menu.
+func WithTimeScale(scale float64) Option {
+	return func(gm *GameManager) {
+		gm.timeScale = max(pet.MinTimeScale, min(scale, pet.MaxTimeScale))
+	}
+}
+
+// WithTrustEditedSaves loads saves that fail the signature check without marking
+// the pet as modified. It is a developer override for debugging hand edited saves.
+func WithTrustEditedSaves(trust bool) Option {
+	return func(gm *GameManager) {
+		gm.trustEditedSaves = trust
+	}
+}
+
+// WithRecorder records the session to rec so it can be played back with ReplaySession
+func WithRecorder(rec *session.Recorder) Option {
+	return func(gm *GameManager) {
+		gm.recorder = rec
+	}
+}
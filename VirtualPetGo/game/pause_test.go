@@ -0,0 +1,57 @@
+package game
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/pet"
+	"VirtualPetGo/save"
+	"testing"
+	"time"
+)
+
+func TestPausedPetCanOnlyBeResumed(t *testing.T) {
+	clk := clock.NewManual(testStart)
+	gm := NewGameManager(&fakeUI{}, WithClock(clk), WithSeed(5), WithSaveDir(t.TempDir()))
+
+	gm.currentPet = pet.NewDog("Max", "Golden Retriever", gm.petOptions()...)
+	gm.slot = "Max"
+	gm.openJournal()
+	gm.writeSave()
+
+	clk.Advance(5 * time.Second)
+	gm.tick()
+	gm.handleAction(10, "") // Pause
+	before := gm.currentPet.GetStatus()
+
+	for i := 0; i < 10; i++ {
+		clk.Advance(time.Minute)
+		gm.tick()
+	}
+	gm.handleAction(1, "") // Feeding is refused
+	if got := gm.currentPet.GetStatus(); got.Hunger != before.Hunger || got.Age != before.Age {
+		t.Errorf("A paused pet should not change, before %+v, after %+v", before, got)
+	}
+
+	gm.writeSave()
+	slots, _ := gm.slots.List()
+	if len(slots) != 1 || !slots[0].Paused {
+		t.Errorf("Expected the slot to show as paused, got %+v", slots)
+	}
+
+	gm.handleAction(10, "") // Resume
+	clk.Advance(5 * time.Second)
+	gm.tick()
+	status := gm.currentPet.GetStatus()
+	if status.Paused || status.ActiveTime != 10*time.Second || status.PausedTime != 10*time.Minute {
+		t.Errorf("Expected 10s played and 10m paused, got %+v", status)
+	}
+	gm.closeJournal()
+
+	entries, _ := save.ReadJournal(gm.slots.JournalPath("Max"))
+	file, err := save.Replay(entries)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if got, want := snapshotJSON(file.Pet), snapshotJSON(gm.currentPet.Snapshot()); got != want {
+		t.Errorf("Replay gave a different pet:\nwant %s\ngot  %s", want, got)
+	}
+}
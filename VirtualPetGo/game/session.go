@@ -0,0 +1,188 @@
+package game
+
+import (
+	"VirtualPetGo/pet"
+	"VirtualPetGo/session"
+	"VirtualPetGo/ui"
+	"VirtualPetGo/utils"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ReplayResult compares how a replayed session ended with the recording
+type ReplayResult struct {
+	Recorded *pet.Status // nil if no pet was created
+	Replayed *pet.Status
+	Complete bool // The recording has its end, it wasn't cut short
+}
+
+// Matches reports whether the replay ended with the recorded status
+func (r ReplayResult) Matches() bool {
+	if r.Recorded == nil || r.Replayed == nil {
+		return r.Recorded == r.Replayed
+	}
+	return *r.Recorded == *r.Replayed
+}
+
+// startRecording writes the start of the session and records every clock reading
+// and input line from here on. Called by NewGameManager before the clock is first read.
+func (gm *GameManager) startRecording() {
+	files, err := gm.slots.Files()
+	if err != nil {
+		gm.ui.DisplayMessage("The recording won't include the saves: " + err.Error())
+		files = make(map[string][]byte)
+	}
+	gm.recorder.Start(session.Start{
+		Seed:             gm.seed,
+		MaxOffline:       gm.maxOffline,
+		TrustEditedSaves: gm.trustEditedSaves,
+		TimeScale:        gm.timeScale,
+		Files:            files,
+	})
+
+	gm.clock = gm.recorder.Clock(gm.clock)
+
+	readLine := utils.ReadLine
+	utils.ReadLine = func() (string, error) {
+		line, err := readLine()
+		switch {
+		case err == nil:
+			gm.recorder.Input(line)
+		case errors.Is(err, io.EOF):
+			gm.recorder.EndOfInput()
+		}
+		return line, err
+	}
+}
+
+// recordBackground notes a background event as it starts
+// Callers must hold gm.mu
+func (gm *GameManager) recordBackground(kind session.Kind) {
+	if gm.recorder != nil {
+		gm.recorder.Background(kind)
+	}
+}
+
+// finishRecording records the final status and closes the session log
+// Callers must hold gm.mu
+func (gm *GameManager) finishRecording(shutdown bool) {
+	if gm.recorder == nil {
+		return
+	}
+	end := session.End{Shutdown: shutdown}
+	if gm.currentPet != nil {
+		status := gm.currentPet.GetStatus()
+		end.Status = &status
+	}
+	if err := gm.recorder.End(end); err != nil {
+		gm.ui.DisplayMessage("The session recording is incomplete: " + err.Error())
+	}
+}
+
+// lockMain takes gm.mu for the main loop. When replaying, the background
+// events recorded before this point run first, as they did in the recording.
+func (gm *GameManager) lockMain() {
+	gm.playBackground()
+	gm.mu.Lock()
+}
+
+// playBackground runs the background events the recording has next, if replaying
+func (gm *GameManager) playBackground() {
+	if gm.player == nil {
+		return
+	}
+	for {
+		kind, ok := gm.player.Background()
+		if !ok {
+			return
+		}
+		switch kind {
+		case session.KindTick:
+			gm.tick()
+		case session.KindAutosave:
+			gm.autosave()
+		}
+	}
+}
+
+// ReplaySession plays a session recorded with WithRecorder back through a new game.
+// The saves it started from are restored to a temporary folder, so nothing real is touched.
+func ReplaySession(userInterface ui.IUserInterface, path string) (*ReplayResult, error) {
+	log, err := session.Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "virtualpet-replay-")
+	if err != nil {
+		return nil, fmt.Errorf("creating replay folder: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	for name, data := range log.Start.Files {
+		if filepath.Base(name) != name {
+			return nil, fmt.Errorf("session log has a bad save file name %q", name)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return nil, fmt.Errorf("restoring saves: %w", err)
+		}
+	}
+
+	player := session.NewPlayer(log)
+	opts := []Option{
+		WithSeed(log.Start.Seed),
+		WithMaxOffline(log.Start.MaxOffline),
+		WithTrustEditedSaves(log.Start.TrustEditedSaves),
+		WithSaveDir(dir),
+		WithAutosave(0),
+		WithClock(player.Clock()),
+	}
+	if log.Start.TimeScale > 0 {
+		opts = append(opts, WithTimeScale(log.Start.TimeScale))
+	}
+
+	gm := NewGameManager(userInterface, opts...)
+	gm.player = player
+
+	readLine := utils.ReadLine
+	defer func() { utils.ReadLine = readLine }()
+	utils.ReadLine = func() (string, error) {
+		gm.playBackground()
+		line, err := player.Input()
+		if errors.Is(err, session.ErrEnded) {
+			// The recording ends here, the game leaves as it does at the end of
+			// input. The player's clock has stopped, so leaving changes nothing.
+			return "", io.EOF
+		}
+		return line, err
+	}
+
+	gm.Start()
+	gm.playBackground()
+	if err := player.Err(); err != nil {
+		return nil, err
+	}
+
+	if player.Shutdown() {
+		gm.Shutdown()
+	}
+
+	result := &ReplayResult{Complete: log.End != nil}
+	if log.End != nil {
+		result.Recorded = log.End.Status
+	}
+	if p := gm.GetPet(); p != nil {
+		status := p.GetStatus()
+		result.Replayed = &status
+	}
+
+	if err := player.Err(); err != nil {
+		return nil, err
+	}
+	if result.Complete && !player.Finished() {
+		return nil, fmt.Errorf("%w: the replay ended before the recording did", session.ErrDiverged)
+	}
+	return result, nil
+}
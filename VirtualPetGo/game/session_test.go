@@ -0,0 +1,161 @@
+package game
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/pet"
+	"VirtualPetGo/session"
+	"VirtualPetGo/utils"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordSession plays a scripted session: each line of input comes two seconds
+// after the last, with a background tick just before it
+func recordSession(t *testing.T, input ...string) string {
+	t.Helper()
+	readLine := utils.ReadLine
+	t.Cleanup(func() { utils.ReadLine = readLine })
+
+	path := filepath.Join(t.TempDir(), "session.log")
+	rec, err := session.Create(path)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	clk := clock.NewManual(testStart)
+	var gm *GameManager
+	utils.ReadLine = func() (string, error) {
+		if len(input) == 0 {
+			return "", io.EOF
+		}
+		clk.Advance(2 * time.Second)
+		gm.tick()
+		line := input[0]
+		input = input[1:]
+		return line, nil
+	}
+
+	gm = NewGameManager(&fakeUI{}, WithClock(clk), WithSeed(3), WithSaveDir(t.TempDir()),
+		WithAutosave(0), WithRecorder(rec))
+	gm.Start()
+	if err := rec.Err(); err != nil {
+		t.Fatalf("Recording failed: %v", err)
+	}
+	return path
+}
+
+func TestReplaySessionMatchesRecording(t *testing.T) {
+	// New Husky, feed it kibble, use its ability, clean, then quit
+	path := recordSession(t, "1", "2", "Max", "1", "1", "", "6", "", "4", "", "9")
+
+	result, err := ReplaySession(&fakeUI{}, path)
+	if err != nil {
+		t.Fatalf("ReplaySession failed: %v", err)
+	}
+	if !result.Complete || result.Recorded == nil {
+		t.Fatalf("Expected a complete recording, got %+v", result)
+	}
+	if result.Recorded.Breed != "Husky" || result.Recorded.Traits == "" {
+		t.Errorf("Expected a Husky with traits, got %q with %q", result.Recorded.Breed, result.Recorded.Traits)
+	}
+	if !result.Matches() {
+		t.Errorf("Replay ended differently:\nrecorded %+v\nreplayed %+v", *result.Recorded, *result.Replayed)
+	}
+}
+
+func TestReplaySessionEndingInEndOfInput(t *testing.T) {
+	path := recordSession(t, "3", "1", "Tweety", "1", "3", "")
+
+	result, err := ReplaySession(&fakeUI{}, path)
+	if err != nil {
+		t.Fatalf("ReplaySession failed: %v", err)
+	}
+	if !result.Matches() {
+		t.Errorf("Replay ended differently:\nrecorded %+v\nreplayed %+v", result.Recorded, result.Replayed)
+	}
+}
+
+func TestReplaySessionDetectsDivergence(t *testing.T) {
+	path := recordSession(t, "2", "3", "Whiskers", "1", "2", "", "9")
+
+	// Drop the last line of input, the quit, so the game asks for more input
+	// where the recording has it saving
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(string(data), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.HasPrefix(lines[i], `{"kind":"input"`) {
+			lines = append(lines[:i], lines[i+1:]...)
+			break
+		}
+	}
+	os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644)
+
+	if _, err := ReplaySession(&fakeUI{}, path); !errors.Is(err, session.ErrDiverged) {
+		t.Errorf("Expected ErrDiverged, got %v", err)
+	}
+}
+
+func TestReplaySessionWithDataDrivenSpecies(t *testing.T) {
+	// A hamster, the first species after the built in ones
+	path := recordSession(t, "5", "Nibbles", "1", "3", "", "6", "", "2", "", "9")
+
+	result, err := ReplaySession(&fakeUI{}, path)
+	if err != nil {
+		t.Fatalf("ReplaySession failed: %v", err)
+	}
+	if result.Recorded == nil || result.Recorded.Type != "Hamster" {
+		t.Fatalf("Expected a hamster, got %+v", result.Recorded)
+	}
+	if !result.Matches() {
+		t.Errorf("Replay ended differently:\nrecorded %+v\nreplayed %+v", *result.Recorded, *result.Replayed)
+	}
+}
+
+func TestReplaySessionWithShopAndMiniGame(t *testing.T) {
+	// A cat that buys tuna, feeds it, then plays Find the Treat twice
+	path := recordSession(t, "2", "1", "Whiskers", "11", "2", "", "1", "2", "", "12", "1", "", "12", "3", "", "9")
+
+	result, err := ReplaySession(&fakeUI{}, path)
+	if err != nil {
+		t.Fatalf("ReplaySession failed: %v", err)
+	}
+	if result.Recorded == nil || result.Recorded.Coins == pet.StarterCoins {
+		t.Fatalf("Expected the coins to change, got %+v", result.Recorded)
+	}
+	if !result.Matches() {
+		t.Errorf("Replay ended differently:\nrecorded %+v\nreplayed %+v", *result.Recorded, *result.Replayed)
+	}
+}
+
+func TestReplaySessionCutShortReturnsCleanly(t *testing.T) {
+	path := recordSession(t, "1", "2", "Max", "1", "1", "", "9")
+
+	// A crash while the menu waited for input: the quit and everything after it is lost
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(string(data), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.HasPrefix(lines[i], `{"kind":"input"`) {
+			lines = lines[:i]
+			break
+		}
+	}
+	os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+
+	goroutines := runtime.NumGoroutine()
+	result, err := ReplaySession(&fakeUI{}, path)
+	if err != nil {
+		t.Fatalf("ReplaySession failed: %v", err)
+	}
+	if result.Complete || result.Replayed == nil || result.Replayed.Name != "Max" {
+		t.Errorf("Expected an incomplete recording with Max in it, got %+v", result)
+	}
+	if now := runtime.NumGoroutine(); now > goroutines {
+		t.Errorf("The replay left %d goroutines running", now-goroutines)
+	}
+}
@@ -0,0 +1,44 @@
+package game
+
+import (
+	"VirtualPetGo/pet"
+	"VirtualPetGo/save"
+	"VirtualPetGo/utils"
+	"fmt"
+)
+
+// DefaultTimeScale runs the pets in real time
+const DefaultTimeScale = 1.0
+
+// settings runs the settings screen. It waits for input, so it takes gm.mu
+// only to apply the change.
+func (gm *GameManager) settings() {
+	gm.lockMain()
+	gm.ui.DisplaySettings(gm.timeScale)
+	gm.mu.Unlock()
+
+	scale, err := utils.ReadFloatInRange(pet.MinTimeScale, pet.MaxTimeScale)
+	if err != nil {
+		return
+	}
+
+	gm.lockMain()
+	defer gm.mu.Unlock()
+	gm.setTimeScale(scale)
+	gm.ui.DisplayMessage(fmt.Sprintf("Time now runs at %gx.", gm.timeScale))
+}
+
+// setTimeScale changes how fast time passes for the pet and records it in the journal
+// Callers must hold gm.mu
+func (gm *GameManager) setTimeScale(scale float64) {
+	// Time up to now still counts at the old scale
+	gm.updatePet()
+
+	gm.currentPet.SetTimeScale(scale)
+	gm.timeScale = gm.currentPet.TimeScale()
+	gm.record(save.Entry{
+		At:    gm.clock.Now(),
+		Kind:  save.EntryTimeScale,
+		Scale: gm.timeScale,
+	})
+}
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/pet"
+	"VirtualPetGo/save"
+	"testing"
+	"time"
+)
+
+func TestTimeScaleChangesAreJournaled(t *testing.T) {
+	clk := clock.NewManual(testStart)
+	gm := NewGameManager(&fakeUI{}, WithClock(clk), WithSeed(5), WithSaveDir(t.TempDir()), WithTimeScale(2))
+
+	gm.currentPet = pet.NewDog("Max", "Golden Retriever", gm.petOptions()...)
+	gm.currentPet.SetTimeScale(gm.timeScale)
+	gm.slot = "Max"
+	gm.openJournal()
+	gm.writeSave()
+
+	for i := 0; i < 20; i++ {
+		clk.Advance(1500 * time.Millisecond)
+		gm.tick()
+		switch i {
+		case 5:
+			gm.handleAction(6, "") // Loyalty
+		case 10:
+			gm.setTimeScale(10)
+		}
+	}
+	if gm.currentPet.TimeScale() != 10 {
+		t.Errorf("Expected the pet at 10x, got %gx", gm.currentPet.TimeScale())
+	}
+	gm.closeJournal()
+
+	entries, _ := save.ReadJournal(gm.slots.JournalPath("Max"))
+	file, err := save.Replay(entries)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if got, want := snapshotJSON(file.Pet), snapshotJSON(gm.currentPet.Snapshot()); got != want {
+		t.Errorf("Replay gave a different pet:\nwant %s\ngot  %s", want, got)
+	}
+}
+
+func TestLoadedPetsRunAtTheGameTimeScale(t *testing.T) {
+	dir := t.TempDir()
+	clk := clock.NewManual(testStart)
+	saved := pet.NewCat("Whiskers", "Orange", pet.WithClock(clk))
+	clk.Advance(time.Minute)
+	if err := save.NewSlots(dir).Save("Whiskers", saved, clk.Now()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Ten seconds away at 6x are another minute
+	clk.Advance(10 * time.Second)
+	gm := NewGameManager(&fakeUI{}, WithClock(clk), WithSaveDir(dir), WithTimeScale(6))
+	if !gm.loadSlot("Whiskers") {
+		t.Fatal("Slot should load")
+	}
+	defer gm.closeJournal()
+
+	if got := gm.currentPet.TimeScale(); got != 6 {
+		t.Errorf("Expected 6x, got %gx", got)
+	}
+	if age := gm.currentPet.GetAge(); age != 2.0 {
+		t.Errorf("Expected age 2.0, got %f", age)
+	}
+}
@@ -0,0 +1,41 @@
+package game
+
+import (
+	"VirtualPetGo/pet"
+	"strconv"
+)
+
+// chooseShopItem shows the shop and asks what to buy, false when the player
+// backs out
+func (gm *GameManager) chooseShopItem() (string, bool) {
+	items := pet.Shop()
+	choice, ok := gm.ask(len(items), func() { gm.ui.DisplayShop(gm.currentPet, items) })
+	if !ok || choice == 0 {
+		return "", ok
+	}
+	return items[choice-1].Name, true
+}
+
+// chooseCup asks which cup the treat is under in the mini-game
+func (gm *GameManager) chooseCup() (string, bool) {
+	choice, ok := gm.ask(pet.MiniGameCups, func() { gm.ui.DisplayMiniGame(gm.currentPet) })
+	if !ok || choice == 0 {
+		return "", ok
+	}
+	return strconv.Itoa(choice), true
+}
+
+// chooseMedicine asks which medicine to give, false when the player backs out
+func (gm *GameManager) chooseMedicine() (string, bool) {
+	var medicines []pet.ShopItem
+	for _, item := range pet.Shop() {
+		if item.Kind == pet.MedicineItem {
+			medicines = append(medicines, item)
+		}
+	}
+	choice, ok := gm.ask(len(medicines), func() { gm.ui.DisplayMedicine(gm.currentPet, medicines) })
+	if !ok || choice == 0 {
+		return "", ok
+	}
+	return medicines[choice-1].Name, true
+}
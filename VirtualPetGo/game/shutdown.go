@@ -0,0 +1,34 @@
+package game
+
+import (
+	"VirtualPetGo/session"
+	"os"
+	"syscall"
+)
+
+// Shutdown writes a final save and flushes output, for when the process is
+// about to be stopped by a signal. It is safe to call while the menu waits
+// for input, the game must not be used afterwards.
+func (gm *GameManager) Shutdown() error {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	gm.recordBackground(session.KindShutdown)
+
+	var saveErr error
+	if gm.currentPet != nil && gm.slot != "" {
+		saveErr = gm.writeSave()
+	}
+	gm.closeJournal()
+	gm.finishRecording(true)
+
+	os.Stdout.Sync()
+	return saveErr
+}
+
+// ExitCode is the status a process stopped by sig should exit with (128 + signal number)
+func ExitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+	return 1
+}
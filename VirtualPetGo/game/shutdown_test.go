@@ -0,0 +1,68 @@
+package game
+
+import (
+	"VirtualPetGo/pet"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestShutdownWritesFinalSave(t *testing.T) {
+	gm, _, clk := newTestGame(t)
+	gm.currentPet = pet.NewDog("Max", "Golden Retriever", gm.petOptions()...)
+	gm.slot = "Max"
+
+	clk.Advance(10 * time.Second)
+	if err := gm.Shutdown(); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	file, err := gm.slots.Read("Max")
+	if err != nil {
+		t.Fatalf("Final save missing: %v", err)
+	}
+	if !file.SavedAt.Equal(clk.Now()) {
+		t.Errorf("Expected save time %v, got %v", clk.Now(), file.SavedAt)
+	}
+	if file.Pet.Hunger >= 100 {
+		t.Error("Final save should include the time since the last update")
+	}
+}
+
+func TestShutdownDuringTheSlotScreen(t *testing.T) {
+	gm, _, _ := newTestGame(t)
+	if err := gm.slots.Save("Max", pet.NewDog("Max", "Golden Retriever", gm.petOptions()...), testStart); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// A signal arrives while one pet is loaded and another is created
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 50 {
+			gm.Shutdown()
+		}
+	}()
+	withInput(t, "2", "1", "1", "1", "1", "Rex")
+	gm.chooseSlot()
+	gm.newSlot()
+	<-done
+}
+
+func TestShutdownWithoutPet(t *testing.T) {
+	gm, _, _ := newTestGame(t)
+
+	if err := gm.Shutdown(); err != nil {
+		t.Errorf("Shutdown before a pet exists should not fail: %v", err)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if code := ExitCode(os.Interrupt); code != 130 {
+		t.Errorf("Expected 130 for SIGINT, got %d", code)
+	}
+	if code := ExitCode(syscall.SIGTERM); code != 143 {
+		t.Errorf("Expected 143 for SIGTERM, got %d", code)
+	}
+}
@@ -0,0 +1,220 @@
+package game
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/pet"
+	"VirtualPetGo/save"
+	"VirtualPetGo/utils"
+	"fmt"
+)
+
+// SharedSaveSuffix is added to the slot name when exporting in the shared format
+const SharedSaveSuffix = ".virtualpet.json"
+
+// chooseSlot runs the slot screen until a pet is loaded or created
+// Returns false if the input ended first
+func (gm *GameManager) chooseSlot() bool {
+	for {
+		slots, err := gm.slots.List()
+		if err != nil {
+			gm.ui.DisplayMessage("Could not list saves: " + err.Error())
+		}
+
+		// Nothing to manage yet, go straight to a new pet
+		if len(slots) == 0 {
+			return gm.newSlot()
+		}
+
+		gm.ui.DisplaySlotManager(slots)
+		choice, err := utils.ReadIntInRange(1, 6)
+		if err != nil {
+			return false
+		}
+
+		if choice == 1 { // New pet
+			return gm.newSlot()
+		}
+
+		// Everything else works on a slot
+		slot, ok := gm.pickSlot(slots)
+		if !ok {
+			return false
+		}
+
+		switch choice {
+		case 2: // Load
+			if gm.loadSlot(slot.Name) {
+				return true
+			}
+
+		case 3: // Rename
+			fmt.Print("\nEnter the new slot name: ")
+			newName, err := utils.ReadString()
+			if err != nil {
+				return false
+			}
+			gm.reportSlotError(gm.slots.Rename(slot.Name, newName))
+
+		case 4: // Duplicate
+			fmt.Print("\nEnter a name for the copy: ")
+			newName, err := utils.ReadString()
+			if err != nil {
+				return false
+			}
+			gm.reportSlotError(gm.slots.Duplicate(slot.Name, newName))
+
+		case 5: // Delete
+			fmt.Printf("\nDelete %s for good? (y/n): ", slot.Name)
+			if sure, _ := utils.ReadYesNo(); sure {
+				gm.reportSlotError(gm.slots.Delete(slot.Name))
+			}
+
+		case 6: // Export for the C# game
+			path := slot.Name + SharedSaveSuffix
+			if err := gm.slots.Export(slot.Name, path); err != nil {
+				gm.reportSlotError(err)
+			} else {
+				gm.ui.DisplayMessage("Exported " + slot.Name + " to " + path)
+			}
+		}
+	}
+}
+
+// pickSlot asks which of the listed slots to use
+// Returns false if the input ended first
+func (gm *GameManager) pickSlot(slots []save.SlotInfo) (save.SlotInfo, bool) {
+	fmt.Printf("\nSelect a slot (1-%d): ", len(slots))
+	index, err := utils.ReadIntInRange(1, len(slots))
+	if err != nil {
+		return save.SlotInfo{}, false
+	}
+	return slots[index-1], true
+}
+
+func (gm *GameManager) reportSlotError(err error) {
+	if err != nil {
+		gm.ui.DisplayMessage("That didn't work: " + err.Error())
+	}
+}
+
+// newSlot creates a pet and saves it straight away in a slot named after it
+// Returns false if the input ended before the pet was made
+func (gm *GameManager) newSlot() bool {
+	gm.createPet()
+
+	// Shutdown may save the pet at any time
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	if gm.currentPet == nil {
+		return false
+	}
+
+	gm.slot = gm.slots.FreeName(gm.currentPet.GetStatus().Name)
+	gm.trustedEdit = false
+	gm.openJournal()
+	gm.savePet()
+	return true
+}
+
+// loadSlot resumes the pet saved in a slot
+// Returns false if the save can't be loaded
+func (gm *GameManager) loadSlot(name string) bool {
+	// Nothing here waits for input, Shutdown waits for the pet to be loaded
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	file, err := gm.slots.Read(name)
+	if err != nil {
+		gm.ui.DisplayMessage("Could not load saved pet: " + err.Error())
+		return false
+	}
+	if file.Recovered {
+		gm.ui.DisplayMessage("The save " + name + " was missing or out of date, " +
+			file.Pet.Name + " was recovered from the journal.")
+	}
+	gm.trustedEdit = false
+	if file.Edited() {
+		gm.flagEditedSave(name, file)
+	}
+
+	// Catch up on the time spent away on a clock starting at the save time
+	catchUp := clock.NewManual(file.SavedAt)
+	away, err := pet.Restore(file.Pet, pet.WithClock(catchUp), pet.WithRand(gm.rng))
+	if err != nil {
+		gm.ui.DisplayMessage("Could not load saved pet: " + err.Error())
+		return false
+	}
+	// The time away passes at the game's time scale
+	away.SetTimeScale(gm.timeScale)
+
+	// Record the loaded pet so the journal can replay the catch-up
+	gm.slot = name
+	gm.openJournal()
+	gm.checkpoint(file.SavedAt, away.Snapshot())
+
+	now := gm.clock.Now()
+	report := pet.SimulateOffline(away, catchUp, now.Sub(file.SavedAt), gm.maxOffline)
+	gm.ui.DisplayOfflineReport(report)
+	gm.record(save.Entry{
+		At:    now,
+		Kind:  save.EntryOffline,
+		Away:  report.Away,
+		Limit: gm.maxOffline,
+		Delta: save.StatDelta(report.Before, report.After),
+	})
+
+	// Then hand the pet over to the game clock
+	loaded, err := pet.Restore(away.Snapshot(), gm.petOptions()...)
+	if err != nil {
+		gm.ui.DisplayMessage("Could not load saved pet: " + err.Error())
+		return false
+	}
+
+	gm.currentPet = loaded
+	gm.lastUpdateTime = now
+	fmt.Printf("\nWelcome back, %s!\n", loaded.GetStatus().Name)
+	return true
+}
+
+// savePet writes the current pet to its slot
+// Callers must hold gm.mu
+func (gm *GameManager) savePet() {
+	if err := gm.writeSave(); err != nil {
+		gm.ui.DisplayMessage("Could not save: " + err.Error())
+		return
+	}
+	gm.ui.DisplayMessage(gm.currentPet.GetStatus().Name + " has been saved to slot " + gm.slot)
+}
+
+// writeSave saves the current pet to its slot and checkpoints the journal
+// Callers must hold gm.mu
+func (gm *GameManager) writeSave() error {
+	// Bring the pet up to date so the save time matches its stats
+	gm.updatePet()
+
+	now := gm.clock.Now()
+	snapshot := gm.currentPet.Snapshot()
+	// The override only lasts as long as the game, the edit stays on record
+	snapshot.Modified = snapshot.Modified || gm.trustedEdit
+	if err := gm.slots.SaveSnapshot(gm.slot, snapshot, now); err != nil {
+		return err
+	}
+	gm.checkpoint(now, snapshot)
+	gm.compactJournal()
+	return nil
+}
+
+// flagEditedSave marks the pet in a save that was edited outside the game as modified.
+// The developer override leaves the pet unmarked while playing, its saves are still marked.
+func (gm *GameManager) flagEditedSave(name string, file *save.File) {
+	if gm.trustEditedSaves {
+		gm.ui.DisplayMessage("Developer override: loading " + name + " without checking its signature.")
+		gm.trustedEdit = true
+		return
+	}
+	if !file.Pet.Modified {
+		file.Pet.Modified = true
+		gm.ui.DisplayMessage("The save " + name + " was changed outside the game, " +
+			file.Pet.Name + " is now marked as modified.")
+	}
+}
@@ -0,0 +1,149 @@
+package game
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/pet"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// saveEditedPet saves a dog in a slot, then edits its hunger by hand
+func saveEditedPet(t *testing.T, gm *GameManager, slot string) {
+	t.Helper()
+	if err := gm.slots.Save(slot, pet.NewDog("Max", "Golden Retriever", gm.petOptions()...), testStart); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	path := gm.slots.Path(slot)
+	data, _ := os.ReadFile(path)
+	var doc map[string]any
+	json.Unmarshal(data, &doc)
+	doc["pet"].(map[string]any)["hunger"] = 42
+	data, _ = json.Marshal(doc)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadingEditedSaveMarksPetModified(t *testing.T) {
+	gm, _, _ := newTestGame(t)
+	saveEditedPet(t, gm, "Max")
+
+	if !gm.loadSlot("Max") {
+		t.Fatal("Edited save should still load")
+	}
+	if !gm.currentPet.GetStatus().Modified {
+		t.Error("Pet from an edited save should be marked as modified")
+	}
+}
+
+func TestTrustEditedSavesOverride(t *testing.T) {
+	fake := &fakeUI{}
+	gm := NewGameManager(fake, WithClock(clock.NewManual(testStart)), WithSeed(1),
+		WithSaveDir(t.TempDir()), WithTrustEditedSaves(true))
+	saveEditedPet(t, gm, "Max")
+
+	if !gm.loadSlot("Max") {
+		t.Fatal("Edited save should load")
+	}
+	if gm.currentPet.GetStatus().Modified {
+		t.Error("The developer override should not mark the pet as modified")
+	}
+	if gm.currentPet.GetStatus().Hunger != 42 {
+		t.Errorf("Expected the edited hunger of 42, got %d", gm.currentPet.GetStatus().Hunger)
+	}
+
+	// Saving under the override doesn't make the edit trusted for good
+	gm.mu.Lock()
+	gm.savePet()
+	gm.mu.Unlock()
+	normal, _, _ := newTestGame(t)
+	normal.slots = gm.slots
+	if !normal.loadSlot("Max") {
+		t.Fatal("The save should load without the override")
+	}
+	if !normal.currentPet.GetStatus().Modified {
+		t.Error("A save written under the override should still be marked as modified")
+	}
+}
+
+func TestLoadingUntouchedSaveIsNotModified(t *testing.T) {
+	gm, _, _ := newTestGame(t)
+	if err := gm.slots.Save("Max", pet.NewDog("Max", "Golden Retriever", gm.petOptions()...), testStart); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	if !gm.loadSlot("Max") {
+		t.Fatal("Save should load")
+	}
+	if gm.currentPet.GetStatus().Modified {
+		t.Error("Pet from an untouched save should not be marked as modified")
+	}
+}
+
+func TestSlotScreenEndsWithTheInput(t *testing.T) {
+	gm, _, _ := newTestGame(t)
+	if err := gm.slots.Save("Max", pet.NewDog("Max", "Golden Retriever", gm.petOptions()...), testStart); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Load, then the input ends before a slot is picked
+	withInput(t, "2")
+	if gm.chooseSlot() {
+		t.Error("The slot screen should give up when the input ends")
+	}
+
+	// Rename and duplicate, then the input ends before the new name
+	for _, choice := range []string{"3", "4"} {
+		withInput(t, choice, "1")
+		if gm.chooseSlot() {
+			t.Errorf("Choice %s: the slot screen should give up when the input ends", choice)
+		}
+		if slots, _ := gm.slots.List(); len(slots) != 1 || slots[0].Name != "Max" {
+			t.Errorf("Choice %s: expected only the slot Max, got %+v", choice, slots)
+		}
+	}
+}
+
+// writeLegacySave puts a save from before signing in a slot
+func writeLegacySave(t *testing.T, gm *GameManager, slot string) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "save", "testdata", "v2.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Dir(gm.slots.Path(slot)), 0755)
+	os.WriteFile(gm.slots.Path(slot), data, 0644)
+}
+
+func TestLegacySaveFromBeforeTheUpgradeLoads(t *testing.T) {
+	gm, _, _ := newTestGame(t)
+	writeLegacySave(t, gm, "Old")
+	if err := gm.slots.SignLegacy(); err != nil {
+		t.Fatalf("SignLegacy failed: %v", err)
+	}
+
+	if !gm.loadSlot("Old") {
+		t.Fatal("A save from before signing should load")
+	}
+	if gm.currentPet.GetStatus().Modified {
+		t.Error("A save from before signing should not be marked as modified")
+	}
+}
+
+func TestLegacySaveFromAfterTheUpgradeIsModified(t *testing.T) {
+	gm, _, _ := newTestGame(t)
+	if err := gm.slots.SignLegacy(); err != nil {
+		t.Fatalf("SignLegacy failed: %v", err)
+	}
+	writeLegacySave(t, gm, "Old")
+
+	if !gm.loadSlot("Old") {
+		t.Fatal("An unsigned save should still load")
+	}
+	if !gm.currentPet.GetStatus().Modified {
+		t.Error("An unsigned save written after the upgrade should be marked as modified")
+	}
+}
@@ -0,0 +1,43 @@
+package game
+
+import (
+	"VirtualPetGo/pet"
+	"VirtualPetGo/utils"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// A species added from outside the game package, it is offered without touching game or ui
+func init() {
+	err := pet.DefineSpecies(pet.SpeciesDef{
+		Name:     "Ferret",
+		Blurb:    "Steals socks",
+		Sound:    "Dook!",
+		Interact: "{name} dances and goes {sound}",
+		Ability:  pet.AbilityDef{Name: "Weasel War Dance", Effect: pet.Effect{Happiness: 40}, Cooldown: 60},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func TestCreatePetOffersRegisteredSpecies(t *testing.T) {
+	readLine := utils.ReadLine
+	t.Cleanup(func() { utils.ReadLine = readLine })
+
+	choice := len(pet.Registered()) // The Ferret is the last one
+	utils.ReadLine = utils.LinesFrom(strings.NewReader(strconv.Itoa(choice) + "\nZippy\n"))
+
+	gm := NewGameManager(&fakeUI{}, WithSaveDir(t.TempDir()))
+	gm.createPet()
+	if gm.currentPet == nil {
+		t.Fatal("No pet was created")
+	}
+	if status := gm.currentPet.GetStatus(); status.Type != "Ferret" || status.Name != "Zippy" {
+		t.Errorf("Expected Zippy the Ferret, got %s the %s", status.Name, status.Type)
+	}
+	if got := gm.currentPet.Interact(); got != "Zippy dances and goes Dook!" {
+		t.Errorf("Unexpected interact text %q", got)
+	}
+}
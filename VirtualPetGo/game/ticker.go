@@ -0,0 +1,109 @@
+package game
+
+import (
+	"VirtualPetGo/session"
+	"context"
+	"sync"
+	"time"
+)
+
+// TickInterval is how often the pet is updated while the menu waits for input
+const TickInterval = time.Second
+
+// startTicker updates the pet in the background until the returned stop is called.
+// stop waits for the goroutine to finish, so nothing touches the pet afterwards.
+func (gm *GameManager) startTicker() (stop func()) {
+	// A replay runs the recorded background events instead
+	if gm.player != nil {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(TickInterval)
+		defer ticker.Stop()
+
+		// A nil channel never fires, so autosave stays off without an interval
+		var autosave <-chan time.Time
+		if gm.autosaveInterval > 0 {
+			autosaveTicker := time.NewTicker(gm.autosaveInterval)
+			defer autosaveTicker.Stop()
+			autosave = autosaveTicker.C
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				gm.tick()
+			case <-autosave:
+				gm.autosave()
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// tick is one background update, it pushes alerts as soon as they happen
+func (gm *GameManager) tick() {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	gm.recordBackground(session.KindTick)
+
+	if gm.currentPet == nil || !gm.currentPet.IsAlive() {
+		return
+	}
+	gm.updatePet()
+
+	if !gm.currentPet.IsAlive() {
+		gm.ui.DisplayAlert("💀 " + gm.currentPet.GetStatus().Name + " has died...")
+		return
+	}
+	gm.pushNewWarnings()
+}
+
+// autosave quietly writes the pet to its slot, only failures are shown
+func (gm *GameManager) autosave() {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	gm.recordBackground(session.KindAutosave)
+
+	if gm.currentPet == nil || gm.slot == "" {
+		return
+	}
+	if err := gm.writeSave(); err != nil {
+		gm.ui.DisplayAlert("Autosave failed: " + err.Error())
+	}
+}
+
+// pushNewWarnings alerts only about warnings that weren't shown already
+func (gm *GameManager) pushNewWarnings() {
+	warnings := gm.currentPet.GetStatus().Warnings()
+
+	shown := make(map[string]bool, len(warnings))
+	for _, warning := range warnings {
+		if !gm.shownWarnings[warning] {
+			gm.ui.DisplayAlert("⚠️  " + warning)
+		}
+		shown[warning] = true
+	}
+	gm.shownWarnings = shown
+}
+
+// markWarningsShown records the warnings the status screen just displayed
+func (gm *GameManager) markWarningsShown() {
+	gm.shownWarnings = make(map[string]bool)
+	for _, warning := range gm.currentPet.GetStatus().Warnings() {
+		gm.shownWarnings[warning] = true
+	}
+}
@@ -0,0 +1,105 @@
+package game
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/pet"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testStart = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestGame(t *testing.T) (*GameManager, *fakeUI, *clock.Manual) {
+	t.Helper()
+	fake := &fakeUI{}
+	clk := clock.NewManual(testStart)
+	gm := NewGameManager(fake, WithClock(clk), WithSeed(1), WithSaveDir(t.TempDir()))
+	return gm, fake, clk
+}
+
+func TestTickUpdatesPet(t *testing.T) {
+	gm, _, clk := newTestGame(t)
+	gm.currentPet = pet.NewDog("Max", "Golden Retriever", gm.petOptions()...)
+
+	clk.Advance(10 * time.Second)
+	gm.tick()
+
+	if gm.currentPet.GetStatus().Hunger >= 100 {
+		t.Error("Hunger should decay on a background tick")
+	}
+}
+
+func TestTickPushesNewWarningsOnce(t *testing.T) {
+	gm, fake, clk := newTestGame(t)
+	gm.currentPet = pet.NewDog("Max", "Golden Retriever", gm.petOptions()...)
+
+	// 30 seconds as a baby: hunger 100 - 78 = 22
+	clk.Advance(30 * time.Second)
+	gm.tick()
+
+	alerts := fake.takeAlerts()
+	if len(alerts) != 1 || !strings.Contains(alerts[0], "very hungry") {
+		t.Fatalf("Expected one hunger alert, got %q", alerts)
+	}
+
+	clk.Advance(time.Second)
+	gm.tick()
+	if alerts := fake.takeAlerts(); len(alerts) != 0 {
+		t.Errorf("Warning should not be repeated, got %q", alerts)
+	}
+}
+
+func TestTickPushesCatRevive(t *testing.T) {
+	gm, fake, clk := newTestGame(t)
+	gm.currentPet = pet.NewCat("Whiskers", "Orange", gm.petOptions()...)
+
+	// Long enough for the cat to die at least once
+	clk.Advance(5 * time.Minute)
+	gm.tick()
+
+	found := false
+	for _, alert := range fake.takeAlerts() {
+		if strings.Contains(alert, "used a life") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Cat revive should be pushed as an alert")
+	}
+}
+
+func TestStartTickerStopsCleanly(t *testing.T) {
+	gm, _, _ := newTestGame(t)
+	gm.currentPet = pet.NewBird("Tweety", "Canary", gm.petOptions()...)
+
+	stop := gm.startTicker()
+
+	done := make(chan struct{})
+	go func() {
+		stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Ticker did not stop")
+	}
+}
+
+func TestAutosaveWritesSlot(t *testing.T) {
+	gm, fake, clk := newTestGame(t)
+	gm.currentPet = pet.NewBird("Tweety", "Canary", gm.petOptions()...)
+	gm.slot = "Tweety"
+
+	clk.Advance(5 * time.Second)
+	gm.autosave()
+
+	if !gm.slots.Exists("Tweety") {
+		t.Fatal("Autosave should write the slot")
+	}
+	if len(fake.messages) != 0 || len(fake.takeAlerts()) != 0 {
+		t.Error("Autosave should be quiet when it works")
+	}
+}
@@ -0,0 +1,338 @@
+// Package interop reads and writes the save format shared with the C# implementation.
+// The format is documented in save-format/README.md at the repository root.
+package interop
+
+import (
+	"VirtualPetGo/pet"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+)
+
+const (
+	Format        = "virtualpet"
+	FormatVersion = 1
+)
+
+var ErrInvalid = errors.New("invalid shared save")
+
+// ErrNotShared is returned for species the shared format has no record for
+var ErrNotShared = errors.New("species is not in the shared format")
+
+// Document is the top level of a shared save
+type Document struct {
+	Format        string     `json:"format"`
+	FormatVersion int        `json:"format_version"`
+	SavedAt       time.Time  `json:"saved_at"`
+	Pet           PetRecord  `json:"pet"`
+	Extensions    *Extension `json:"extensions,omitempty"`
+}
+
+type PetRecord struct {
+	Species string      `json:"species"` // "dog", "cat", "bird"
+	Name    string      `json:"name"`
+	BornAt  time.Time   `json:"born_at"`
+	Stats   Stats       `json:"stats"`
+	Illness *Illness    `json:"illness"` // null when healthy
+	Dog     *DogRecord  `json:"dog,omitempty"`
+	Cat     *CatRecord  `json:"cat,omitempty"`
+	Bird    *BirdRecord `json:"bird,omitempty"`
+}
+
+type Stats struct {
+	Health      int `json:"health"`
+	Hunger      int `json:"hunger"`
+	Happiness   int `json:"happiness"`
+	Cleanliness int `json:"cleanliness"`
+}
+
+type Illness struct {
+	Name string `json:"name,omitempty"`
+}
+
+type DogRecord struct {
+	LoyaltyActive bool       `json:"loyalty_active"`
+	LoyaltyEndsAt *time.Time `json:"loyalty_ends_at"` // null when inactive
+}
+
+type CatRecord struct {
+	LivesRemaining int `json:"lives_remaining"`
+}
+
+type BirdRecord struct {
+	SongCooldownSeconds float64 `json:"song_cooldown_seconds"`
+	SongsPerformed      int     `json:"songs_performed"`
+}
+
+// Extension holds implementation specific state, other implementations ignore it
+type Extension struct {
+	Go *GoExtension `json:"go,omitempty"`
+}
+
+type GoExtension struct {
+	Simulation         pet.SimulationState `json:"simulation"`
+	ActiveSeconds      float64             `json:"active_seconds,omitempty"` // Play time
+	PausedSeconds      float64             `json:"paused_seconds,omitempty"`
+	Breed              string              `json:"breed,omitempty"`
+	Traits             []string            `json:"traits,omitempty"`
+	Energy             *int                `json:"energy,omitempty"` // Missing is fully rested
+	AsleepSeconds      float64             `json:"asleep_seconds,omitempty"`
+	GroggySeconds      float64             `json:"groggy_seconds,omitempty"`
+	Weight             *int                `json:"weight,omitempty"` // Missing is the ideal weight
+	SatietySeconds     float64             `json:"satiety_seconds,omitempty"`
+	HeavySeconds       float64             `json:"heavy_seconds,omitempty"`
+	WeightTrendSeconds float64             `json:"weight_trend_seconds,omitempty"`
+	Pantry             map[string]int      `json:"pantry,omitempty"` // Missing is the starter pantry
+	Coins              *int                `json:"coins,omitempty"`  // Missing is StarterCoins
+	Supplies           map[string]int      `json:"supplies,omitempty"`
+	WellSeconds        float64             `json:"well_seconds,omitempty"`
+	Milestone          pet.AgeStage        `json:"milestone,omitempty"`
+	SickSeconds        float64             `json:"sick_seconds,omitempty"` // Time ill, sets the illness stage
+	NapHealth          int                 `json:"nap_health,omitempty"`   // Health restored when the nap ends
+}
+
+// empty is true when there is nothing Go specific to write
+func (e GoExtension) empty() bool {
+	return e.Simulation == (pet.SimulationState{}) && e.ActiveSeconds == 0 &&
+		e.PausedSeconds == 0 && e.Breed == "" && len(e.Traits) == 0 &&
+		e.Energy == nil && e.AsleepSeconds == 0 && e.GroggySeconds == 0 &&
+		e.Weight == nil && e.SatietySeconds == 0 && e.HeavySeconds == 0 && e.WeightTrendSeconds == 0 &&
+		len(e.Pantry) == 0 && e.Coins == nil && len(e.Supplies) == 0 &&
+		e.WellSeconds == 0 && e.Milestone == "" && e.SickSeconds == 0 && e.NapHealth == 0
+}
+
+// IsShared reports whether data looks like a shared save rather than a Go save file
+func IsShared(data []byte) bool {
+	var probe struct {
+		Format string `json:"format"`
+	}
+	return json.Unmarshal(data, &probe) == nil && probe.Format == Format
+}
+
+// Encode writes a pet snapshot in the shared format
+func Encode(s pet.Snapshot, savedAt time.Time) ([]byte, error) {
+	if s.Generic != nil || s.Fish != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotShared, s.Type)
+	}
+
+	// The C# game runs in real time and can't pause, so the birth and loyalty
+	// times are given at 1x for a running pet
+	s = s.Resumed(savedAt).Rescaled(savedAt, 1)
+
+	doc := Document{
+		Format:        Format,
+		FormatVersion: FormatVersion,
+		SavedAt:       savedAt.UTC(),
+		Pet: PetRecord{
+			Species: strings.ToLower(s.Type),
+			Name:    s.Name,
+			BornAt:  s.BirthTime.UTC(),
+			Stats: Stats{
+				Health:      s.Health,
+				Hunger:      s.Hunger,
+				Happiness:   s.Happiness,
+				Cleanliness: s.Cleanliness,
+			},
+		},
+	}
+
+	if s.IsIll {
+		doc.Pet.Illness = &Illness{Name: s.IllnessName}
+	}
+
+	switch {
+	case s.Dog != nil:
+		doc.Pet.Dog = &DogRecord{LoyaltyActive: s.Dog.LoyaltyActive}
+		if s.Dog.LoyaltyActive {
+			endsAt := s.Dog.LoyaltyEndTime.UTC()
+			doc.Pet.Dog.LoyaltyEndsAt = &endsAt
+		}
+	case s.Cat != nil:
+		doc.Pet.Cat = &CatRecord{LivesRemaining: s.Cat.LivesRemaining}
+	case s.Bird != nil:
+		doc.Pet.Bird = &BirdRecord{
+			SongCooldownSeconds: s.Bird.SongCooldown,
+			SongsPerformed:      s.Bird.SongsPerformed,
+		}
+	}
+
+	// Only write the Go extension when there is something in it
+	extension := GoExtension{
+		Simulation:    s.Simulation,
+		ActiveSeconds: s.ActiveSeconds,
+		PausedSeconds: s.PausedSeconds,
+		Breed:         s.Breed,
+		Traits:        s.Traits,
+		AsleepSeconds: s.AsleepSeconds,
+		GroggySeconds: s.GroggySeconds,
+
+		SatietySeconds:     s.SatietySeconds,
+		HeavySeconds:       s.HeavySeconds,
+		WeightTrendSeconds: s.WeightTrendSeconds,
+
+		Supplies:    s.Supplies,
+		WellSeconds: s.WellSeconds,
+		Milestone:   s.Milestone,
+		SickSeconds: s.SickSeconds,
+		NapHealth:   s.NapHealth,
+	}
+	if s.Energy != pet.MaxStat {
+		extension.Energy = &s.Energy
+	}
+	if s.Weight != pet.IdealWeight {
+		extension.Weight = &s.Weight
+	}
+	if !maps.Equal(s.Pantry, pet.StarterPantry()) {
+		extension.Pantry = s.Pantry
+	}
+	if s.Coins != pet.StarterCoins {
+		extension.Coins = &s.Coins
+	}
+	if !extension.empty() {
+		doc.Extensions = &Extension{Go: &extension}
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// Decode reads a shared save and returns the pet snapshot and the save time
+func Decode(data []byte) (pet.Snapshot, time.Time, error) {
+	var doc Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return pet.Snapshot{}, time.Time{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if err := doc.validate(); err != nil {
+		return pet.Snapshot{}, time.Time{}, err
+	}
+
+	record := doc.Pet
+	s := pet.Snapshot{
+		Name:        record.Name,
+		BirthTime:   record.BornAt,
+		Health:      record.Stats.Health,
+		Hunger:      record.Stats.Hunger,
+		Happiness:   record.Stats.Happiness,
+		Cleanliness: record.Stats.Cleanliness,
+		Energy:      pet.MaxStat, // The C# game has no energy, weight, pantry or coins
+		Weight:      pet.IdealWeight,
+		Pantry:      pet.StarterPantry(),
+		Coins:       pet.StarterCoins,
+		TimeScale:   1,
+	}
+	if record.Illness != nil {
+		s.IsIll = true
+		s.IllnessName = record.Illness.Name
+	}
+
+	switch record.Species {
+	case "dog":
+		s.Type = "Dog"
+		s.Dog = &pet.DogState{LoyaltyActive: record.Dog.LoyaltyActive}
+		if record.Dog.LoyaltyEndsAt != nil {
+			s.Dog.LoyaltyEndTime = *record.Dog.LoyaltyEndsAt
+		}
+	case "cat":
+		s.Type = "Cat"
+		s.Cat = &pet.CatState{LivesRemaining: record.Cat.LivesRemaining}
+	case "bird":
+		s.Type = "Bird"
+		s.Bird = &pet.BirdState{
+			SongCooldown:   record.Bird.SongCooldownSeconds,
+			SongsPerformed: record.Bird.SongsPerformed,
+		}
+	}
+
+	if doc.Extensions != nil && doc.Extensions.Go != nil {
+		s.Simulation = doc.Extensions.Go.Simulation
+		s.ActiveSeconds = doc.Extensions.Go.ActiveSeconds
+		s.PausedSeconds = doc.Extensions.Go.PausedSeconds
+		s.Breed = doc.Extensions.Go.Breed
+		s.Traits = doc.Extensions.Go.Traits
+		s.AsleepSeconds = doc.Extensions.Go.AsleepSeconds
+		s.GroggySeconds = doc.Extensions.Go.GroggySeconds
+		if doc.Extensions.Go.Energy != nil {
+			s.Energy = *doc.Extensions.Go.Energy
+		}
+		s.SatietySeconds = doc.Extensions.Go.SatietySeconds
+		s.HeavySeconds = doc.Extensions.Go.HeavySeconds
+		s.WeightTrendSeconds = doc.Extensions.Go.WeightTrendSeconds
+		if doc.Extensions.Go.Weight != nil {
+			s.Weight = *doc.Extensions.Go.Weight
+		}
+		if doc.Extensions.Go.Pantry != nil {
+			s.Pantry = doc.Extensions.Go.Pantry
+		}
+		if doc.Extensions.Go.Coins != nil {
+			s.Coins = *doc.Extensions.Go.Coins
+		}
+		s.Supplies = doc.Extensions.Go.Supplies
+		s.WellSeconds = doc.Extensions.Go.WellSeconds
+		s.Milestone = doc.Extensions.Go.Milestone
+		s.SickSeconds = doc.Extensions.Go.SickSeconds
+		s.NapHealth = doc.Extensions.Go.NapHealth
+	}
+
+	return s, doc.SavedAt, nil
+}
+
+// validate checks the rules of save-format/README.md that JSON decoding doesn't
+func (doc *Document) validate() error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ErrInvalid, fmt.Sprintf(format, args...))
+	}
+
+	if doc.Format != Format {
+		return invalid("format is %q, expected %q", doc.Format, Format)
+	}
+	if doc.FormatVersion != FormatVersion {
+		return invalid("format version %d is not supported, expected %d", doc.FormatVersion, FormatVersion)
+	}
+	if doc.SavedAt.IsZero() {
+		return invalid("saved_at is missing")
+	}
+
+	record := doc.Pet
+	if record.Name == "" {
+		return invalid("pet name is missing")
+	}
+	if record.BornAt.IsZero() {
+		return invalid("born_at is missing")
+	}
+	for label, value := range map[string]int{
+		"health":      record.Stats.Health,
+		"hunger":      record.Stats.Hunger,
+		"happiness":   record.Stats.Happiness,
+		"cleanliness": record.Stats.Cleanliness,
+	} {
+		if value < pet.MinStat || value > pet.MaxStat {
+			return invalid("%s %d is outside %d-%d", label, value, pet.MinStat, pet.MaxStat)
+		}
+	}
+
+	switch record.Species {
+	case "dog":
+		if record.Dog == nil {
+			return invalid("dog has no dog object")
+		}
+	case "cat":
+		if record.Cat == nil {
+			return invalid("cat has no cat object")
+		}
+		if record.Cat.LivesRemaining < 0 || record.Cat.LivesRemaining > pet.MaxLives {
+			return invalid("lives_remaining %d is outside 0-%d", record.Cat.LivesRemaining, pet.MaxLives)
+		}
+	case "bird":
+		if record.Bird == nil {
+			return invalid("bird has no bird object")
+		}
+		if record.Bird.SongCooldownSeconds < 0 || record.Bird.SongsPerformed < 0 {
+			return invalid("bird song counters can't be negative")
+		}
+	default:
+		return invalid("unknown species %q", record.Species)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package interop
+
+import (
+	"VirtualPetGo/pet"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fixtures is the conformance suite shared with the C# implementation
+const fixtures = "../../save-format/fixtures"
+
+func TestValidFixturesRoundTrip(t *testing.T) {
+	paths, _ := filepath.Glob(filepath.Join(fixtures, "valid", "*.json"))
+	if len(paths) == 0 {
+		t.Fatal("No valid fixtures found")
+	}
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			snapshot, savedAt, err := Decode(data)
+			if err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if _, err := pet.Restore(snapshot); err != nil {
+				t.Fatalf("Restore failed: %v", err)
+			}
+
+			encoded, err := Encode(snapshot, savedAt)
+			if err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+
+			// Same JSON, ignoring whitespace and key order
+			var want, got any
+			json.Unmarshal(data, &want)
+			json.Unmarshal(encoded, &got)
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("Writing the pet back changed it:\nwant %s\ngot  %s", data, encoded)
+			}
+		})
+	}
+}
+
+func TestInvalidFixturesAreRefused(t *testing.T) {
+	paths, _ := filepath.Glob(filepath.Join(fixtures, "invalid", "*.json"))
+	if len(paths) == 0 {
+		t.Fatal("No invalid fixtures found")
+	}
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, _, err := Decode(data); !errors.Is(err, ErrInvalid) {
+				t.Errorf("Expected ErrInvalid, got %v", err)
+			}
+		})
+	}
+}
+
+func TestEveryGoSpeciesRoundTrips(t *testing.T) {
+	dog := pet.NewDog("Max", "Golden Retriever", pet.WithTraits("Lazy", "Neat"))
+	dog.UseSpecialAbility()
+	dog.Update(1.25)
+
+	cat := pet.NewCat("Whiskers", "Orange")
+	cat.UseSpecialAbility()
+
+	bird := pet.NewBird("Tweety", "Canary")
+	bird.UseSpecialAbility()
+	bird.Update(3)
+
+	savedAt := time.Now()
+	for _, original := range []pet.Pet{dog, cat, bird} {
+		data, err := Encode(original.Snapshot(), savedAt)
+		if err != nil {
+			t.Fatalf("Encode failed: %v", err)
+		}
+
+		snapshot, gotSavedAt, err := Decode(data)
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if !gotSavedAt.Equal(savedAt) {
+			t.Errorf("Expected save time %v, got %v", savedAt, gotSavedAt)
+		}
+
+		want, _ := json.Marshal(inUTC(original.Snapshot()))
+		got, _ := json.Marshal(inUTC(snapshot))
+		if string(got) != string(want) {
+			t.Errorf("%s changed on round trip:\nwant %s\ngot  %s", snapshot.Type, want, got)
+		}
+	}
+}
+
+// inUTC puts every time in UTC like the shared format does
+func inUTC(s pet.Snapshot) pet.Snapshot {
+	s.BirthTime = s.BirthTime.UTC()
+	if s.Dog != nil {
+		s.Dog.LoyaltyEndTime = s.Dog.LoyaltyEndTime.UTC()
+	}
+	return s
+}
+
+func TestDataDrivenSpeciesAreNotShared(t *testing.T) {
+	hamster, err := pet.NewPet("Hamster", "Nibbles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Encode(hamster.Snapshot(), time.Now()); !errors.Is(err, ErrNotShared) {
+		t.Errorf("Expected ErrNotShared, got %v", err)
+	}
+}
+
+func TestFishIsNotShared(t *testing.T) {
+	if _, err := Encode(pet.NewFish("Bubbles").Snapshot(), time.Now()); !errors.Is(err, ErrNotShared) {
+		t.Errorf("Expected ErrNotShared, got %v", err)
+	}
+}
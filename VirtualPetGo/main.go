@@ -2,20 +2,142 @@ package main
 
 import (
 	"VirtualPetGo/game"
+	"VirtualPetGo/pet"
+	"VirtualPetGo/save"
+	"VirtualPetGo/session"
 	"VirtualPetGo/ui"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 //TIP <p>To run your code, right-click the code and select <b>Run</b>.</p> <p>Alternatively, click
 // the <icon src="AllIcons.Actions.Execute"/> icon in the gutter and select the <b>Run</b> menu item from here.</p>
 
 func main() {
+	maxOffline := flag.Duration("max-offline", game.DefaultMaxOffline,
+		"cap on the time simulated when a saved pet is resumed (0 = no cap)")
+	seed := flag.Uint64("seed", 0, "seed for the random source, the same seed and actions replay the same game")
+	saveDir := flag.String("saves", save.DefaultDir, "folder the save slots are kept in")
+	autosave := flag.Duration("autosave", game.DefaultAutosave, "how often to save while playing (0 = off)")
+	timeScale := flag.Float64("time-scale", game.DefaultTimeScale,
+		"how fast time passes for the pet, from 0.1 (relaxed) to 10 (demos), can be changed in the settings")
+	trustEdits := flag.Bool("trust-edited-saves", false,
+		"developer override: don't mark pets from edited saves as modified")
+	speciesDir := flag.String("species", "species", "folder with extra species definitions (*.json)")
+	shopFile := flag.String("shop", "shop.json", "shop catalogue to use instead of the built in one, if it exists")
+	record := flag.String("record", "", "record the session to this file so it can be replayed")
+	replay := flag.String("replay", "", "play back a session recorded with -record")
+	verify := flag.Bool("verify", false,
+		"with -replay: play back without output and fail if the final status differs")
+	flag.Parse()
+	if *verify && *replay == "" {
+		// Exit like flag does on a bad command line
+		fmt.Fprintln(os.Stderr, "-verify needs a session to check, pass it with -replay")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := pet.LoadSpecies(*speciesDir); err != nil {
+		fmt.Fprintln(os.Stderr, "Could not load species:", err)
+		os.Exit(1)
+	}
+	if err := pet.LoadShop(*shopFile); err != nil {
+		fmt.Fprintln(os.Stderr, "Could not load the shop:", err)
+		os.Exit(1)
+	}
+
+	if *replay != "" {
+		os.Exit(replaySession(*replay, *verify))
+	}
+
+	opts := []game.Option{
+		game.WithMaxOffline(*maxOffline),
+		game.WithSaveDir(*saveDir),
+		game.WithAutosave(*autosave),
+		game.WithTimeScale(*timeScale),
+		game.WithTrustEditedSaves(*trustEdits),
+	}
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			opts = append(opts, game.WithSeed(*seed))
+		}
+	})
+
+	var recorder *session.Recorder
+	if *record != "" {
+		var err error
+		if recorder, err = session.Create(*record); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		opts = append(opts, game.WithRecorder(recorder))
+	}
+
 	// Initialize UI
 	userInterface := ui.NewConsoleUI()
 
 	// Initialize Game Manager with UI
-	gameManager := game.NewGameManager(userInterface)
+	gameManager := game.NewGameManager(userInterface, opts...)
+
+	// Save and exit cleanly on Ctrl+C or a kill
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		if err := gameManager.Shutdown(); err != nil {
+			fmt.Fprintln(os.Stderr, "\nCould not save on exit:", err)
+			os.Exit(1)
+		}
+		fmt.Println("\nGame saved. Goodbye!")
+		os.Exit(game.ExitCode(sig))
+	}()
 
 	// Start the game
 	gameManager.Start()
 
+	if recorder != nil {
+		if err := recorder.Err(); err != nil {
+			fmt.Fprintln(os.Stderr, "The session recording is incomplete:", err)
+		} else {
+			fmt.Println("Session recorded to", *record)
+		}
+	}
+}
+
+// replaySession plays a recorded session back and returns the exit code.
+// With verify the game's output is hidden and only the result is shown.
+func replaySession(path string, verify bool) int {
+	stdout := os.Stdout
+	if verify {
+		devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+		if err == nil {
+			os.Stdout = devNull
+			defer devNull.Close()
+		}
+	}
+	result, err := game.ReplaySession(ui.NewConsoleUI(), path)
+	os.Stdout = stdout
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Replay failed:", err)
+		return 1
+	}
+	if !result.Complete {
+		fmt.Println("The recording was cut short, there is no final status to compare.")
+		if verify {
+			return 1
+		}
+		return 0
+	}
+	if !result.Matches() {
+		fmt.Println("The replay ended differently from the recording.")
+		fmt.Printf("Recorded: %+v\n", result.Recorded)
+		fmt.Printf("Replayed: %+v\n", result.Replayed)
+		return 1
+	}
+	fmt.Println("The replay matches the recording.")
+	return 0
 }
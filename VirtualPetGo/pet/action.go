@@ -0,0 +1,171 @@
+package pet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Action is something the player does with a pet, by name so it can be recorded
+type Action string
+
+const (
+	ActionFeed     Action = "feed"
+	ActionPlay     Action = "play"
+	ActionSleep    Action = "sleep"
+	ActionClean    Action = "clean"
+	ActionInteract Action = "interact"
+	ActionAbility  Action = "ability"
+	ActionPause    Action = "pause"
+	ActionResume   Action = "resume"
+	ActionBuy      Action = "buy"      // The item is what to buy
+	ActionMiniGame Action = "minigame" // The item is the cup picked
+	ActionMedicine Action = "medicine" // The item is the medicine to give
+)
+
+// actionLabels are the menu names of the actions, species can rename them
+var actionLabels = map[Action]string{
+	ActionFeed:     "Feed",
+	ActionPlay:     "Play",
+	ActionSleep:    "Sleep",
+	ActionClean:    "Clean",
+	ActionInteract: "Interact (Make Sound)",
+	ActionAbility:  "Use Special Ability",
+	ActionPause:    "Pause",
+	ActionResume:   "Resume",
+	ActionBuy:      "Shop",
+	ActionMiniGame: "Play Find the Treat",
+	ActionMedicine: "Give Medicine",
+}
+
+// Supports is true for every action, species that can't do some override it
+func (bp *BasePet) Supports(action Action) bool {
+	return true
+}
+
+// ActionLabel returns the menu name of the action
+func (bp *BasePet) ActionLabel(action Action) string {
+	return actionLabels[action]
+}
+
+// Perform does the action on the pet and returns the message to show.
+// Actions the species doesn't support and unknown actions do nothing,
+// unknown actions return an empty message.
+func Perform(p Pet, action Action) string {
+	return PerformWith(p, action, "")
+}
+
+// PerformWith does the action with an item: the food for Feed, the shop item
+// to buy, the cup picked in the mini-game or the medicine to give. Without an
+// item Feed is a standard meal that takes nothing from the pantry.
+func PerformWith(p Pet, action Action, item string) string {
+	if actionLabels[action] != "" && !p.Supports(action) {
+		return fmt.Sprintf("A %s can't %s!", p.GetStatus().Type, strings.ToLower(p.ActionLabel(action)))
+	}
+
+	bp, ok := baseOf(p)
+	if !ok {
+		return perform(p, action)
+	}
+
+	// Shopping leaves the pet alone
+	if action == ActionBuy {
+		return bp.buy(p, item)
+	}
+
+	// A food that ran out or that the pet won't eat doesn't wake it up,
+	// neither does medicine it can't have or care the illness stops
+	if refusal := bp.illnessRefusal(action); refusal != "" {
+		return refusal
+	}
+	var food Food
+	switch {
+	case action == ActionFeed && item != "":
+		var refusal string
+		if food, refusal = bp.serve(p, item); refusal != "" {
+			return refusal
+		}
+	case action == ActionMedicine:
+		if refusal := bp.medicineRefusal(item); refusal != "" {
+			return refusal
+		}
+	}
+
+	// Caring for a sleeping pet wakes it up
+	var woken string
+	if wakingActions[action] && bp.SleepState() == Asleep {
+		woken = bp.wakeUp() + " "
+	}
+	if refusal := bp.restRefusal(action); refusal != "" {
+		return woken + refusal
+	}
+
+	// An ability that isn't ready takes no energy
+	if action != ActionAbility || p.CanUseAbility() {
+		bp.setEnergy(bp.GetEnergy() - energyCosts[action])
+	}
+	full := action == ActionFeed && bp.isFull()
+	health := bp.health
+	var message string
+	switch action {
+	case ActionMiniGame:
+		message = bp.findTheTreat(item)
+	case ActionMedicine:
+		message = bp.giveMedicine(item)
+	default:
+		message = perform(p, action)
+	}
+	// What Sleep gave, before the overweight penalty which is never held back
+	rested := bp.health - health
+	message += bp.applyWeight(action)
+	switch action {
+	case ActionPlay:
+		message += bp.playWithToy()
+	case ActionClean:
+		message += bp.useSoap()
+	case ActionFeed:
+		if food.Name != "" {
+			message += bp.eat(p, food)
+		}
+		if full {
+			message += bp.overfed()
+		}
+		bp.fed()
+	case ActionSleep:
+		message += bp.fallAsleep(rested)
+	}
+	return woken + message
+}
+
+// perform does the action without the sleep rules
+func perform(p Pet, action Action) string {
+	switch action {
+	case ActionFeed:
+		return withTraits(p, action, p.Feed())
+	case ActionPlay:
+		return withTraits(p, action, p.Play())
+	case ActionSleep:
+		return withTraits(p, action, p.Sleep())
+	case ActionClean:
+		return withTraits(p, action, p.Clean())
+	case ActionInteract:
+		return p.Interact()
+	case ActionAbility:
+		if !p.CanUseAbility() {
+			return "Special ability is not available right now!"
+		}
+		return p.UseSpecialAbility()
+	case ActionPause:
+		return p.Pause()
+	case ActionResume:
+		return p.Resume()
+	}
+	return ""
+}
+
+// withTraits adds what the pet's traits do to a care action after it is done
+func withTraits(p Pet, action Action, message string) string {
+	if bp, ok := baseOf(p); ok {
+		return message + bp.applyTraits(action)
+	}
+	return message
+}
@@ -1,7 +1,9 @@
 package pet
 
 import (
-	"math/rand"
+	"VirtualPetGo/clock"
+	"fmt"
+	"math/rand/v2"
 	"time"
 )
 
@@ -16,20 +18,104 @@ type BasePet struct {
 	sound          string
 	isIll          bool
 	illnessName    string
+	sickFor        float64 // Pet seconds since the pet fell ill, see suffer
+	clock          clock.Clock
+	rng            *rand.Rand
+	sim            simulation
+	species        speciesHooks // Set by Dog, Cat, Bird and Generic
+	decay          Decay        // Species and breed multipliers on the decay rates
+	breed          string
+	traits         []Trait
+	energy         int
+	asleepFor      float64 // Pet seconds of sleep left
+	groggyFor      float64 // Pet seconds of grogginess left
+	napHealth      int     // Health the nap restores if the pet sleeps it out, see fallAsleep
+	weight         int
+	satietyFor     float64 // Pet seconds the last meal keeps the pet full
+	heavyFor       float64 // Pet seconds at HeavyWeight or above
+	trendFor       float64 // Pet seconds the weight shows as gaining, negative for losing
+	pantry         map[string]int
+	supplies       map[string]int // Toys, soap and medicine from the shop
+	coins          int
+	wellFor        float64  // Pet seconds of good care towards the next coins
+	milestone      AgeStage // Last age stage reached, see earn
+	events         []string
+	modified       bool          // Loaded from a save that was edited outside the game
+	timeScale      float64       // Pet seconds per real second, see SetTimeScale
+	pausedAt       time.Time     // When the pet was paused, zero while running
+	pausedTime     time.Duration // Time spent paused before pausedAt
+	activeTime     time.Duration // Time passed to Update while running
 }
 
-func newBasePet(name string) BasePet {
-	now := time.Now()
-	return BasePet{
-		name:           name,
-		birthTime:      now,
-		health:         100,
-		hunger:         100,
-		happiness:      100,
-		cleanliness:    100,
-		lastUpdateTime: now,
-		isIll:          false,
-		illnessName:    "",
+func newBasePet(name string, opts ...Option) BasePet {
+	bp := BasePet{
+		name:        name,
+		health:      100,
+		hunger:      100,
+		happiness:   100,
+		cleanliness: 100,
+		energy:      100,
+		weight:      IdealWeight,
+		pantry:      StarterPantry(),
+		supplies:    map[string]int{},
+		coins:       StarterCoins,
+		milestone:   Baby,
+		isIll:       false,
+		illnessName: "",
+		clock:       clock.Real{},
+		timeScale:   1,
+		decay:       normalDecay,
+	}
+	applyOptions(&bp, opts)
+	bp.ensureRand()
+
+	now := bp.clock.Now()
+	bp.birthTime = now
+	bp.lastUpdateTime = now
+	return bp
+}
+
+// NewBasePet creates the shared state for a species from another package,
+// which embeds it next to its own state. See Register.
+func NewBasePet(name string, opts ...Option) BasePet {
+	return newBasePet(name, opts...)
+}
+
+// BaseStatus fills in the shared part of the status, every species adds its
+// SpecialAbility and AbilityStatus. Species from other packages use it too.
+func (bp *BasePet) BaseStatus(petType string) Status {
+	return Status{
+		Name:     bp.name,
+		Type:     petType,
+		Breed:    bp.breed,
+		Traits:   bp.traitText(),
+		Age:      bp.GetAge(),
+		AgeStage: bp.getAgeStage(),
+
+		Health:      bp.health,
+		Hunger:      bp.hunger,
+		Happiness:   bp.happiness,
+		Cleanliness: bp.cleanliness,
+		Energy:      bp.energy,
+		Sleep:       bp.SleepState(),
+		Weight:      bp.weight,
+		WeightTrend: bp.Trend(),
+		Overweight:  bp.IsOverweight(),
+		Coins:       bp.coins,
+
+		StatusMessage: bp.getStatusMessage(),
+		IsAlive:       bp.IsAlive(),
+
+		IsIll:        bp.isIll,
+		IllnessName:  bp.illnessName,
+		IllnessStage: bp.IllnessStage(),
+
+		Modified:  bp.modified,
+		TimeScale: bp.timeScale,
+
+		Paused:     bp.IsPaused(),
+		PausedTime: bp.pausedFor(),
+		ActiveTime: bp.activeTime,
 	}
 }
 func (bp *BasePet) GetName() string {
@@ -63,10 +149,28 @@ func (bp *BasePet) GetCleanliness() int {
 
 // GetAge calculates and returns the pet's age in minutes
 func (bp *BasePet) GetAge() float64 {
-	return time.Since(bp.birthTime).Minutes()
+	return bp.ageAt(bp.now()).Minutes()
+}
+
+// now is the clock time as far as the pet is concerned, it stands still while paused
+func (bp *BasePet) now() time.Time {
+	if bp.IsPaused() {
+		return bp.pausedAt
+	}
+	return bp.clock.Now()
+}
+
+// ageAt returns how much pet time has passed since birth at time t
+func (bp *BasePet) ageAt(t time.Time) time.Duration {
+	return time.Duration(float64(t.Sub(bp.birthTime)) * bp.timeScale)
 }
 func (bp *BasePet) getAgeStage() AgeStage {
-	age := bp.GetAge()
+	return bp.ageStageAt(bp.now())
+}
+
+// ageStageAt returns the age stage the pet has at time t
+func (bp *BasePet) ageStageAt(t time.Time) AgeStage {
+	age := bp.ageAt(t).Minutes()
 
 	if age < BabyMaxAge {
 		return Baby
@@ -77,7 +181,10 @@ func (bp *BasePet) getAgeStage() AgeStage {
 	}
 }
 func (bp *BasePet) getDecayMultiplier() float64 {
-	switch bp.getAgeStage() {
+	return decayMultiplierFor(bp.getAgeStage())
+}
+func decayMultiplierFor(stage AgeStage) float64 {
+	switch stage {
 	case Baby:
 		return BabyDecayMultiplier // 1.3x
 	case Adult:
@@ -90,8 +197,8 @@ func (bp *BasePet) getDecayMultiplier() float64 {
 }
 
 func (bp *BasePet) Feed() string {
-	bp.setHunger(bp.GetHunger() + 20)
-	bp.setHappiness(bp.GetHappiness() + 5)
+	bp.setHunger(bp.GetHunger() + MealNutrition)
+	bp.setHappiness(bp.GetHappiness() + MealHappiness)
 	return bp.GetName() + " enjoyed the meal! Hunger restored"
 }
 func (bp *BasePet) Sleep() string {
@@ -101,7 +208,7 @@ func (bp *BasePet) Sleep() string {
 	return bp.GetName() + " took a nice nap! Health Restored"
 }
 func (bp *BasePet) Play() string {
-	bp.setHappiness(bp.GetHappiness() + 20)
+	bp.setHappiness(bp.GetHappiness() + 20 + breedNamed(bp.breed).Play)
 	bp.setHunger(bp.GetHunger() - 10)
 
 	return bp.GetName() + " is playing! Happiness increased, but got a bit hungry."
@@ -112,14 +219,17 @@ func (bp *BasePet) Clean() string {
 	bp.setHappiness(bp.GetHappiness() + 10)
 
 	message := bp.GetName() + " is now clean and fresh! Feels much better."
+	return message + bp.cureIfClean()
+}
 
-	// Cure illness if pet gets clean
-	if bp.isIll && bp.cleanliness > 60 {
+// cureIfClean cures an illness that cleaning cures once the pet is clean enough and says so
+func (bp *BasePet) cureIfClean() string {
+	if bp.isIll && bp.illness().Clean && bp.cleanliness > CureCleanliness {
+		illness := bp.illnessTitle()
 		bp.recoverFromIllness()
-		message += " The illness has been cured!"
+		return " The " + illness + " has been cured!"
 	}
-
-	return message
+	return ""
 }
 func (bp *BasePet) IsAlive() bool {
 	return bp.health > 0
@@ -129,39 +239,6 @@ func (bp *BasePet) getHappinessDecayModifier() float64 {
 	return 1.0 // Default: no modification
 }
 
-func (bp *BasePet) Update(deltaTime float64) {
-	multiplier := bp.getDecayMultiplier()
-
-	// Check for illness periodically
-	bp.checkForIllness()
-
-	// Apply hunger decay
-	hungerDecay := HungerDecayRate * deltaTime * multiplier
-	bp.setHunger(bp.GetHunger() - int(hungerDecay))
-
-	// Apply cleanliness decay
-	cleanlinessDecay := CleanlinessDecayRate * deltaTime * multiplier
-	bp.setCleanliness(bp.GetCleanliness() - int(cleanlinessDecay))
-
-	// Apply happiness decay with modifier hook
-	if bp.GetHunger() < CriticalStatThreshold || bp.GetCleanliness() < CriticalStatThreshold {
-		happinessDecay := HappinessDecayRate * deltaTime * multiplier
-		happinessDecay *= bp.getHappinessDecayModifier() // Hook for subclasses
-		bp.setHappiness(bp.GetHappiness() - int(happinessDecay))
-	}
-
-	// If ill, health decays faster
-	if bp.isIll {
-		illnessHealthDecay := 1.0 * deltaTime * multiplier // 1 health per second when ill
-		bp.setHealth(bp.GetHealth() - int(illnessHealthDecay))
-	} else if bp.getCriticalStatCount() >= 2 {
-		healthDecay := HealthDecayRate * deltaTime * multiplier
-		bp.setHealth(bp.GetHealth() - int(healthDecay))
-	}
-
-	bp.lastUpdateTime = time.Now()
-}
-
 func (bp *BasePet) getStatusMessage() string {
 	if !bp.IsAlive() {
 		return "Dead..."
@@ -214,13 +291,33 @@ func (bp *BasePet) checkForIllness() {
 	// Lower cleanliness = higher chance
 	cleanlinessRatio := float64(bp.cleanliness) / 100.0
 	illnessChance := BaseIllnessChance + (MaxIllnessChance-BaseIllnessChance)*(1.0-cleanlinessRatio)
+	illnessChance *= bp.illnessFactor()
 
 	// Random check
-	if rand.Float64() < illnessChance {
-		bp.isIll = true
-		bp.illnessName = IllnessTypes[rand.Intn(len(IllnessTypes))]
+	if bp.rng.Float64() < illnessChance {
+		bp.fallIll(illnesses[bp.rng.IntN(len(illnesses))].Name)
 	}
 }
+
+// ensureRand gives the pet its own randomly seeded source if none was injected
+func (bp *BasePet) ensureRand() {
+	if bp.rng == nil {
+		bp.rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+}
+
+// notify records something that happened for the game to show
+func (bp *BasePet) notify(event string) {
+	bp.events = append(bp.events, event)
+}
+
+// TakeEvents returns what happened since the last call and clears the list
+func (bp *BasePet) TakeEvents() []string {
+	events := bp.events
+	bp.events = nil
+	return events
+}
+
 func (bp *BasePet) IsIll() bool {
 	return bp.isIll
 }
@@ -229,7 +326,84 @@ func (bp *BasePet) GetIllness() string {
 	return bp.illnessName
 }
 
+// TimeScale returns how many pet seconds pass per real second
+func (bp *BasePet) TimeScale() float64 {
+	return bp.timeScale
+}
+
+// SetTimeScale changes how fast time passes for the pet, clamped to
+// MinTimeScale..MaxTimeScale. The pet keeps its age, time given to Update
+// afterwards counts at the new scale, so bring the pet up to date first.
+func (bp *BasePet) SetTimeScale(scale float64) {
+	scale = clampTimeScale(scale)
+	bp.birthTime = rescaleTime(bp.birthTime, bp.now(), bp.timeScale, scale)
+	bp.timeScale = scale
+}
+
+// Pause freezes the pet: it stops aging, Update does nothing and ability timers
+// stand still until Resume
+func (bp *BasePet) Pause() string {
+	if bp.IsPaused() {
+		return bp.GetName() + " is already paused."
+	}
+	bp.pausedAt = bp.clock.Now()
+	return bp.GetName() + " is paused. Take your time, nothing changes until you resume."
+}
+
+// Resume starts time again where Pause stopped it
+func (bp *BasePet) Resume() string {
+	if !bp.IsPaused() {
+		return bp.GetName() + " isn't paused."
+	}
+	paused := bp.clock.Now().Sub(bp.pausedAt)
+	bp.birthTime = bp.birthTime.Add(paused)
+	bp.pausedTime += paused
+	bp.pausedAt = time.Time{}
+	return fmt.Sprintf("%s is back after %s away!", bp.GetName(), paused.Round(time.Second))
+}
+
+func (bp *BasePet) IsPaused() bool {
+	return !bp.pausedAt.IsZero()
+}
+
+// pausedFor returns the total time spent paused, including the current pause
+func (bp *BasePet) pausedFor() time.Duration {
+	if bp.IsPaused() {
+		return bp.pausedTime + bp.clock.Now().Sub(bp.pausedAt)
+	}
+	return bp.pausedTime
+}
+
+// base gives SimulateOffline the BasePet of any species
+func (bp *BasePet) base() *BasePet {
+	return bp
+}
+
+// realDuration converts pet time to real time
+func (bp *BasePet) realDuration(d time.Duration) time.Duration {
+	return time.Duration(float64(d) / bp.timeScale)
+}
+
+func clampTimeScale(scale float64) float64 {
+	if scale < MinTimeScale {
+		return MinTimeScale
+	} else if scale > MaxTimeScale {
+		return MaxTimeScale
+	}
+	return scale
+}
+
+// rescaleTime moves t so the pet time between at and t stays the same
+// when the time scale changes from one value to the other
+func rescaleTime(t, at time.Time, from, to float64) time.Time {
+	if from == to {
+		return t
+	}
+	return at.Add(time.Duration(float64(t.Sub(at)) * from / to))
+}
+
 func (bp *BasePet) recoverFromIllness() {
 	bp.isIll = false
 	bp.illnessName = ""
+	bp.sickFor = 0
 }
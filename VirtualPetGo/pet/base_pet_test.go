@@ -1,7 +1,8 @@
 package pet
 
 import (
-	"math/rand"
+	"VirtualPetGo/clock"
+	"math/rand/v2"
 	"testing"
 	"time"
 )
@@ -196,7 +197,6 @@ func TestBasePetUpdate(t *testing.T) {
 }
 
 func TestBasePetIllness(t *testing.T) {
-	rand.Seed(time.Now().UnixNano())
 	basePet := newBasePet("TestPet")
 
 	// Should not be ill initially
@@ -210,10 +210,10 @@ func TestBasePetIllness(t *testing.T) {
 }
 
 func TestBasePetIllnessIncreasesWithDirtiness(t *testing.T) {
-	rand.Seed(42) // Fixed seed for reproducible test
+	rng := rand.New(rand.NewPCG(42, 42)) // Fixed seed for reproducible test
 
 	// Test with high cleanliness (low chance)
-	cleanPet := newBasePet("CleanPet")
+	cleanPet := newBasePet("CleanPet", WithRand(rng))
 	cleanPet.setCleanliness(90)
 	illnessCount := 0
 
@@ -229,7 +229,7 @@ func TestBasePetIllnessIncreasesWithDirtiness(t *testing.T) {
 	cleanRate := float64(illnessCount) / 100.0
 
 	// Test with low cleanliness (high chance)
-	dirtyPet := newBasePet("DirtyPet")
+	dirtyPet := newBasePet("DirtyPet", WithRand(rng))
 	dirtyPet.setCleanliness(10)
 	illnessCount = 0
 
@@ -338,3 +338,49 @@ func TestBasePetGetStatusMessage(t *testing.T) {
 		t.Errorf("Expected 'Dead...', got '%s'", status)
 	}
 }
+
+func TestBasePetAgesWithClock(t *testing.T) {
+	clk := clock.NewManual(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	basePet := newBasePet("TestPet", WithClock(clk))
+
+	if basePet.getAgeStage() != Baby {
+		t.Errorf("Expected Baby stage, got %s", basePet.getAgeStage())
+	}
+
+	clk.Advance(5 * time.Minute)
+	if basePet.getAgeStage() != Adult {
+		t.Errorf("Expected Adult stage at 5 minutes, got %s", basePet.getAgeStage())
+	}
+
+	clk.Advance(10 * time.Minute)
+	if basePet.getAgeStage() != Elderly {
+		t.Errorf("Expected Elderly stage at 15 minutes, got %s", basePet.getAgeStage())
+	}
+
+	if basePet.GetAge() != 15.0 {
+		t.Errorf("Expected age 15.0, got %f", basePet.GetAge())
+	}
+}
+
+func TestBasePetIllnessIsReproducibleWithSeed(t *testing.T) {
+	rollIllnesses := func(seed uint64) []string {
+		basePet := newBasePet("TestPet", WithRand(rand.New(rand.NewPCG(seed, seed))))
+		basePet.setCleanliness(0)
+
+		var illnesses []string
+		for i := 0; i < 50; i++ {
+			basePet.recoverFromIllness()
+			basePet.checkForIllness()
+			illnesses = append(illnesses, basePet.GetIllness())
+		}
+		return illnesses
+	}
+
+	first := rollIllnesses(42)
+	second := rollIllnesses(42)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Roll %d differs with the same seed: '%s' vs '%s'", i, first[i], second[i])
+		}
+	}
+}
@@ -1,17 +1,24 @@
 package pet
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type Bird struct {
 	BasePet
-	songCooldown float64
+	songCooldown   float64
+	songsPerformed int
 }
 
-func NewBird(name string) *Bird {
-	return &Bird{
-		BasePet:      newBasePet(name),
+func NewBird(name, breed string, opts ...Option) *Bird {
+	b := &Bird{
+		BasePet:      newBasePet(name, opts...),
 		songCooldown: 0,
 	}
+	b.species = b
+	b.setBreed(breed)
+	return b
 }
 
 func (b *Bird) MakeSound() string {
@@ -29,11 +36,9 @@ func (b *Bird) Play() string {
 	return b.GetName() + " performs aerial acrobatics! Happiness increased."
 }
 
-func (b *Bird) Update(deltaTime float64) {
-	b.BasePet.Update(deltaTime)
-
+func (b *Bird) onTick(dt float64, now time.Time) {
 	if b.songCooldown > 0 {
-		b.songCooldown -= deltaTime
+		b.songCooldown -= dt
 		if b.songCooldown < 0 {
 			b.songCooldown = 0
 		}
@@ -41,13 +46,18 @@ func (b *Bird) Update(deltaTime float64) {
 }
 
 func (b *Bird) UseSpecialAbility() string {
-	b.setHunger(b.GetHunger() + SongHungerBoost)
-	b.setHappiness(b.GetHappiness() + SongHungerBoost)
-	b.setHealth(b.GetHealth() + SongHealthBoost)
-	b.setCleanliness(b.GetCleanliness() + SongCleanlinessBoost)
+	// Some breeds sing stronger songs
+	boost := func(points int) int {
+		return int(float64(points) * breedNamed(b.breed).Ability)
+	}
+	b.setHunger(b.GetHunger() + boost(SongHungerBoost))
+	b.setHappiness(b.GetHappiness() + boost(SongHungerBoost))
+	b.setHealth(b.GetHealth() + boost(SongHealthBoost))
+	b.setCleanliness(b.GetCleanliness() + boost(SongCleanlinessBoost))
 
 	//Set Cooldown
 	b.songCooldown = SongCooldown
+	b.songsPerformed++
 
 	return b.GetName() + " sings a beautiful song! All boosted!"
 }
@@ -62,28 +72,8 @@ func (b *Bird) GetStatus() Status {
 		abilityStatus = fmt.Sprintf("(Cooldown: %.0f seconds)", b.songCooldown)
 	}
 
-	return Status{
-		Name:     b.GetName(),
-		Type:     "Bird",
-		Age:      b.GetAge(),
-		AgeStage: b.BasePet.getAgeStage(),
-
-		// Core stats
-		Health:      b.GetHealth(),
-		Hunger:      b.GetHunger(),
-		Happiness:   b.GetHappiness(),
-		Cleanliness: b.GetCleanliness(),
-
-		// Special ability info
-		SpecialAbility: "Song - Boosts all stats!",
-		AbilityStatus:  abilityStatus,
-
-		// Overall status
-		StatusMessage: b.BasePet.getStatusMessage(),
-		IsAlive:       b.IsAlive(),
-
-		// Illness status
-		IsIll:       b.IsIll(),
-		IllnessName: b.GetIllness(),
-	}
+	status := b.BaseStatus("Bird")
+	status.SpecialAbility = "Song - Boosts all stats!"
+	status.AbilityStatus = abilityStatus
+	return status
 }
@@ -0,0 +1,74 @@
+package pet
+
+// Breed is a variant of a species with its own numbers
+type Breed struct {
+	Name    string
+	Blurb   string
+	Decay   Decay   // Multiplies the species decay rates
+	Play    int     // Extra happiness from playing
+	Ability float64 // Multiplies the ability's numbers (Loyalty length, Song boosts)
+}
+
+// breeds of the built in species, the first one is the default
+var breeds = map[string][]Breed{
+	"Dog": {
+		{Name: "Golden Retriever", Blurb: "Gentle all-rounder", Decay: normalDecay, Ability: 1},
+		{Name: "Husky", Blurb: "Tireless runner, loves to play but eats a lot",
+			Decay: Decay{Hunger: 1.25, Cleanliness: 1, Happiness: 0.9, Health: 1}, Play: 10, Ability: 1},
+		{Name: "Beagle", Blurb: "Nose always in the dirt, loyal for longer",
+			Decay: Decay{Hunger: 1, Cleanliness: 1.3, Happiness: 1, Health: 1}, Ability: 1.5},
+	},
+	"Cat": {
+		{Name: "Orange", Blurb: "Easygoing tabby", Decay: normalDecay, Ability: 1},
+		{Name: "Siamese", Blurb: "Chatty and needy, adores playtime",
+			Decay: Decay{Hunger: 1, Cleanliness: 1, Happiness: 1.3, Health: 1}, Play: 8, Ability: 1},
+		{Name: "Persian", Blurb: "Long fur that needs grooming, small appetite",
+			Decay: Decay{Hunger: 0.85, Cleanliness: 1.4, Happiness: 1, Health: 1}, Ability: 1},
+	},
+	"Bird": {
+		{Name: "Canary", Blurb: "Small and cheerful", Decay: normalDecay, Ability: 1},
+		{Name: "Parrot", Blurb: "Big and clever, sings stronger songs but eats more",
+			Decay: Decay{Hunger: 1.2, Cleanliness: 1, Happiness: 1, Health: 1}, Ability: 1.5},
+	},
+}
+
+// Breeds returns the breeds of a species, nil for species without breeds
+func Breeds(species string) []Breed {
+	return breeds[species]
+}
+
+// defaultBreed is the breed of pets that were made before breeds
+func defaultBreed(species string) string {
+	if list := breeds[species]; len(list) > 0 {
+		return list[0].Name
+	}
+	return ""
+}
+
+// breedNamed looks a breed up by name, unknown breeds change nothing
+func breedNamed(name string) Breed {
+	for _, list := range breeds {
+		for _, b := range list {
+			if b.Name == name {
+				return b
+			}
+		}
+	}
+	return Breed{Name: name, Decay: normalDecay, Ability: 1}
+}
+
+// setBreed makes the pet the named breed and scales its decay rates
+func (bp *BasePet) setBreed(name string) {
+	bp.breed = name
+	bp.decay = bp.decay.times(breedNamed(name).Decay)
+}
+
+// times multiplies the decay rates
+func (d Decay) times(o Decay) Decay {
+	return Decay{
+		Hunger:      d.Hunger * o.Hunger,
+		Cleanliness: d.Cleanliness * o.Cleanliness,
+		Happiness:   d.Happiness * o.Happiness,
+		Health:      d.Health * o.Health,
+	}
+}
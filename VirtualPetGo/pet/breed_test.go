@@ -0,0 +1,82 @@
+package pet
+
+import (
+	"VirtualPetGo/clock"
+	"testing"
+	"time"
+)
+
+func TestEverySpeciesDefaultBreedIsPlain(t *testing.T) {
+	for species, list := range breeds {
+		if b := list[0]; b.Decay != normalDecay || b.Play != 0 || b.Ability != 1 {
+			t.Errorf("Default %s breed %s should change nothing, got %+v", species, b.Name, b)
+		}
+	}
+}
+
+func TestBreedChangesDecay(t *testing.T) {
+	retriever := NewDog("Max", "Golden Retriever")
+	husky := NewDog("Balto", "Husky")
+
+	retriever.Update(10)
+	husky.Update(10)
+
+	// 2 points/sec * 1.3 baby multiplier * 10 sec = 26, a Husky loses 1.25 times as much (32.5)
+	if retriever.GetHunger() != 74 || husky.GetHunger() != 68 {
+		t.Errorf("Expected hunger 74 and 68, got %d and %d", retriever.GetHunger(), husky.GetHunger())
+	}
+}
+
+func TestBreedChangesPlay(t *testing.T) {
+	husky := NewDog("Balto", "Husky")
+	husky.setHappiness(30)
+
+	husky.Play()
+
+	// Base play 20, fetch 20 and 10 for a Husky
+	if husky.GetHappiness() != 80 {
+		t.Errorf("Expected happiness 80, got %d", husky.GetHappiness())
+	}
+}
+
+func TestBreedChangesAbility(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	beagle := NewDog("Snoopy", "Beagle", WithClock(clk))
+	beagle.UseSpecialAbility()
+
+	clk.Advance(75 * time.Second)
+	beagle.Update(75)
+	if !beagle.loyaltyActive {
+		t.Error("A Beagle's loyalty should last 90 seconds")
+	}
+
+	parrot := NewBird("Polly", "Parrot")
+	parrot.setHealth(50)
+	parrot.UseSpecialAbility()
+	if want := 50 + SongHealthBoost*3/2; parrot.GetHealth() != want {
+		t.Errorf("Expected a Parrot's song to heal to %d, got %d", want, parrot.GetHealth())
+	}
+}
+
+func TestBreedIsKeptOnRestore(t *testing.T) {
+	persian := NewCat("Duchess", "Persian")
+
+	restored, err := Restore(persian.Snapshot())
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if got := restored.GetStatus().Breed; got != "Persian" {
+		t.Errorf("Expected a Persian, got %q", got)
+	}
+	if restored.(*Cat).decay != persian.decay {
+		t.Errorf("Breed decay lost on restore: %+v", restored.(*Cat).decay)
+	}
+
+	// Pets from before breeds are the default breed
+	s := persian.Snapshot()
+	s.Breed = ""
+	restored, _ = Restore(s)
+	if got := restored.GetStatus().Breed; got != "Orange" {
+		t.Errorf("Expected an Orange cat, got %q", got)
+	}
+}
@@ -1,17 +1,23 @@
 package pet
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type Cat struct {
 	BasePet
 	livesRemaining int
 }
 
-func NewCat(name string) *Cat {
-	return &Cat{
-		BasePet:        newBasePet(name),
+func NewCat(name, breed string, opts ...Option) *Cat {
+	c := &Cat{
+		BasePet:        newBasePet(name, opts...),
 		livesRemaining: MaxLives,
 	}
+	c.species = c
+	c.setBreed(breed)
+	return c
 }
 
 func (c *Cat) MakeSound() string {
@@ -30,15 +36,12 @@ func (c *Cat) Play() string {
 	return c.GetName() + "plays independently! Purrs contentedly."
 }
 
-func (c *Cat) Update(deltaTime float64) {
-	c.BasePet.Update(deltaTime)
-
+func (c *Cat) onTick(dt float64, now time.Time) {
 	if !c.IsAlive() && c.CanUseAbility() {
 		c.setHealth(100)
 		c.livesRemaining--
-		fmt.Printf("\n🐱 %s used a life! %d lives remaining.\n", c.GetName(), c.livesRemaining)
+		c.notify(fmt.Sprintf("🐱 %s used a life! %d lives remaining.", c.GetName(), c.livesRemaining))
 	}
-
 }
 
 func (c *Cat) UseSpecialAbility() string {
@@ -60,28 +63,8 @@ func (c *Cat) GetStatus() Status {
 	// Determine ability status text
 	abilityStatus := fmt.Sprintf("(%d lives remaining)", c.livesRemaining)
 
-	return Status{
-		Name:     c.GetName(),
-		Type:     "Cat",
-		Age:      c.GetAge(),
-		AgeStage: c.getAgeStage(),
-
-		// Core stats
-		Health:      c.GetHealth(),
-		Hunger:      c.GetHunger(),
-		Happiness:   c.GetHappiness(),
-		Cleanliness: c.GetCleanliness(),
-
-		// Special ability info
-		SpecialAbility: "Nine Lives - Can regenerate health!",
-		AbilityStatus:  abilityStatus,
-
-		// Overall status
-		StatusMessage: c.BasePet.getStatusMessage(),
-		IsAlive:       c.IsAlive(),
-
-		// Illness status
-		IsIll:       c.IsIll(),
-		IllnessName: c.GetIllness(),
-	}
+	status := c.BaseStatus("Cat")
+	status.SpecialAbility = "Nine Lives - Can regenerate health!"
+	status.AbilityStatus = abilityStatus
+	return status
 }
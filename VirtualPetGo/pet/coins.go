@@ -0,0 +1,69 @@
+package pet
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// milestoneCoins are paid when the pet reaches an age stage
+var milestoneCoins = map[AgeStage]int{
+	Adult:   AdultCoins,
+	Elderly: ElderlyCoins,
+}
+
+// ageStages in the order a pet goes through them
+var ageStages = map[AgeStage]int{Baby: 0, Adult: 1, Elderly: 2}
+
+// Coins returns the pet's coins, spent in the shop
+func (bp *BasePet) Coins() int {
+	return bp.coins
+}
+
+// earn pays for good care, CareCoins for every CareCoinInterval spent with
+// every stat at WellStat or above, and milestone coins for growing up
+func (bp *BasePet) earn(dt float64, now time.Time) {
+	if !bp.IsAlive() {
+		return
+	}
+
+	if bp.isWell() {
+		bp.wellFor += dt
+		for bp.wellFor >= CareCoinInterval {
+			bp.wellFor -= CareCoinInterval
+			bp.coins += CareCoins
+		}
+	}
+
+	if stage := bp.ageStageAt(now); ageStages[stage] > ageStages[bp.milestone] {
+		bp.milestone = stage
+		if coins := milestoneCoins[stage]; coins > 0 {
+			bp.coins += coins
+			bp.notify(fmt.Sprintf("🎉 %s is now %s! +%d coins", bp.GetName(), stage, coins))
+		}
+	}
+}
+
+// isWell is true when the pet is healthy and every stat is looked after
+func (bp *BasePet) isWell() bool {
+	return !bp.isIll && bp.health >= WellStat && bp.hunger >= WellStat &&
+		bp.happiness >= WellStat && bp.cleanliness >= WellStat
+}
+
+// findTheTreat is the mini-game: the pet hides a treat under one of
+// MiniGameCups cups and guess is the cup the player picked
+func (bp *BasePet) findTheTreat(guess string) string {
+	cup, err := strconv.Atoi(guess)
+	if err != nil || cup < 1 || cup > MiniGameCups {
+		return fmt.Sprintf("Pick a cup from 1 to %d.", MiniGameCups)
+	}
+
+	hidden := bp.rng.IntN(MiniGameCups) + 1
+	if cup != hidden {
+		bp.setHappiness(bp.GetHappiness() + MiniGameLoseHappiness)
+		return fmt.Sprintf("Cup %d is empty, the treat was under cup %d. %s had fun anyway!", cup, hidden, bp.GetName())
+	}
+	bp.coins += MiniGameCoins
+	bp.setHappiness(bp.GetHappiness() + MiniGameWinHappiness)
+	return fmt.Sprintf("Found it under cup %d! %s is delighted. +%d coins", cup, bp.GetName(), MiniGameCoins)
+}
@@ -0,0 +1,84 @@
+package pet
+
+import (
+	"VirtualPetGo/clock"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGoodCareEarnsCoins(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	dog := NewDog("Max", "Golden Retriever", WithClock(clk), WithRand(rand.New(rand.NewPCG(1, 1))))
+
+	clk.Advance(10 * time.Second)
+	dog.Update(10)
+	if dog.Coins() != StarterCoins {
+		t.Errorf("Expected no coins before %gs of care, got %d", CareCoinInterval, dog.Coins())
+	}
+	clk.Advance(10 * time.Second)
+	dog.Update(10)
+	if dog.Coins() != StarterCoins+CareCoins {
+		t.Errorf("Expected %d coins, got %d", StarterCoins+CareCoins, dog.Coins())
+	}
+
+	// A neglected pet earns nothing
+	dog.setHunger(10)
+	clk.Advance(time.Minute)
+	dog.Update(60)
+	if dog.Coins() != StarterCoins+CareCoins {
+		t.Errorf("Expected no coins while hungry, got %d", dog.Coins())
+	}
+}
+
+func TestGrowingUpEarnsCoins(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	cat := NewCat("Whiskers", "Orange", WithClock(clk), WithRand(rand.New(rand.NewPCG(1, 1))))
+	cat.setHunger(10) // No care coins
+
+	clk.Advance(BabyMaxAge * time.Minute)
+	cat.Update(BabyMaxAge * 60)
+	if cat.Coins() != StarterCoins+AdultCoins {
+		t.Errorf("Expected %d coins for growing up, got %d", StarterCoins+AdultCoins, cat.Coins())
+	}
+	events := strings.Join(cat.TakeEvents(), "\n")
+	if !strings.Contains(events, "Whiskers is now Adult!") {
+		t.Errorf("Expected a milestone event, got %q", events)
+	}
+
+	// Restoring doesn't pay again
+	restored, _ := Restore(cat.Snapshot(), WithClock(clk))
+	clk.Advance(time.Second)
+	restored.Update(1)
+	if restored.Coins() != StarterCoins+AdultCoins {
+		t.Errorf("Expected the milestone to be paid once, got %d", restored.Coins())
+	}
+}
+
+func TestFindTheTreat(t *testing.T) {
+	won, lost := 0, 0
+	for seed := uint64(0); seed < 30; seed++ {
+		bird := NewBird("Tweety", "Canary", WithRand(rand.New(rand.NewPCG(seed, seed))))
+		message := PerformWith(bird, ActionMiniGame, "2")
+		switch bird.Coins() {
+		case StarterCoins + MiniGameCoins:
+			won++
+		case StarterCoins:
+			lost++
+		default:
+			t.Fatalf("Unexpected coins %d after %q", bird.Coins(), message)
+		}
+		if bird.GetEnergy() != MaxStat-MiniGameEnergyCost {
+			t.Fatalf("Expected the mini-game to cost %d energy, got %d", MiniGameEnergyCost, bird.GetEnergy())
+		}
+	}
+	if won == 0 || lost == 0 {
+		t.Errorf("Expected some wins and losses, got %d and %d", won, lost)
+	}
+
+	bird := NewBird("Tweety", "Canary")
+	if message := PerformWith(bird, ActionMiniGame, "4"); !strings.Contains(message, "Pick a cup") {
+		t.Errorf("Expected a bad cup to be refused, got %q", message)
+	}
+}
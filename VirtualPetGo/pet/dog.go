@@ -1,6 +1,9 @@
 package pet
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type Dog struct {
 	BasePet
@@ -8,11 +11,14 @@ type Dog struct {
 	loyaltyEndTime time.Time
 }
 
-func NewDog(name string) *Dog {
-	return &Dog{
-		BasePet:       newBasePet(name),
+func NewDog(name, breed string, opts ...Option) *Dog {
+	d := &Dog{
+		BasePet:       newBasePet(name, opts...),
 		loyaltyActive: false,
 	}
+	d.species = d
+	d.setBreed(breed)
+	return d
 }
 
 func (d *Dog) Play() string {
@@ -39,21 +45,38 @@ func (d *Dog) getHappinessDecayModifier() float64 {
 	return 1.0
 }
 
-func (d *Dog) Update(deltaTime float64) {
-	// Call base update (which uses our modifier)
-	d.BasePet.Update(deltaTime)
-
+func (d *Dog) onTick(dt float64, now time.Time) {
 	// Check if loyalty has expired
-	if d.loyaltyActive && time.Now().After(d.loyaltyEndTime) {
+	if d.loyaltyActive && now.After(d.loyaltyEndTime) {
 		d.loyaltyActive = false
 	}
 }
 func (d *Dog) UseSpecialAbility() string {
 	d.loyaltyActive = true
-	d.loyaltyEndTime = time.Now().Add(time.Duration(LoyaltyDuration) * time.Second)
+	// Some breeds stay loyal for longer
+	seconds := LoyaltyDuration * breedNamed(d.breed).Ability
+	d.loyaltyEndTime = d.clock.Now().Add(d.realDuration(secondsToDuration(seconds)))
 
-	return d.GetName() + " is feeling extra loyal! Happiness will decay slower for the next 60 seconds."
+	return fmt.Sprintf("%s is feeling extra loyal! Happiness will decay slower for the next %.0f seconds.", d.GetName(), seconds)
 }
+
+// SetTimeScale also keeps the pet time left on loyalty
+func (d *Dog) SetTimeScale(scale float64) {
+	from := d.timeScale
+	d.BasePet.SetTimeScale(scale)
+	if d.loyaltyActive {
+		d.loyaltyEndTime = rescaleTime(d.loyaltyEndTime, d.now(), from, d.timeScale)
+	}
+}
+
+// Resume also moves the end of loyalty by the time spent paused
+func (d *Dog) Resume() string {
+	if d.IsPaused() {
+		d.loyaltyEndTime = d.loyaltyEndTime.Add(d.clock.Now().Sub(d.pausedAt))
+	}
+	return d.BasePet.Resume()
+}
+
 func (d *Dog) CanUseAbility() bool {
 	return !d.loyaltyActive // Can only use when loyalty is not currently active
 }
@@ -64,27 +87,8 @@ func (d *Dog) GetStatus() Status {
 		abilityStatus = "(Active)"
 	}
 
-	return Status{
-		Name:     d.GetName(),
-		Type:     "Dog",
-		Age:      d.GetAge(),
-		AgeStage: d.BasePet.getAgeStage(),
-
-		// Core stats
-		Health:      d.GetHealth(),
-		Hunger:      d.GetHunger(),
-		Happiness:   d.GetHappiness(),
-		Cleanliness: d.GetCleanliness(),
-
-		// Special ability info
-		SpecialAbility: "Loyalty - Maintains happiness longer!",
-		AbilityStatus:  abilityStatus,
-
-		// Overall status
-		StatusMessage: d.BasePet.getStatusMessage(),
-		IsAlive:       d.IsAlive(),
-		// Illness status
-		IsIll:       d.IsIll(),
-		IllnessName: d.GetIllness(),
-	}
+	status := d.BaseStatus("Dog")
+	status.SpecialAbility = "Loyalty - Maintains happiness longer!"
+	status.AbilityStatus = abilityStatus
+	return status
 }
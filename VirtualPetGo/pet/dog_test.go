@@ -1,7 +1,9 @@
 package pet
 
 import (
+	"VirtualPetGo/clock"
 	"testing"
+	"time"
 )
 
 func TestNewDog(t *testing.T) {
@@ -136,3 +138,26 @@ func TestDogGetStatus(t *testing.T) {
 		t.Errorf("Unexpected special ability description: '%s'", status.SpecialAbility)
 	}
 }
+
+func TestDogLoyaltyExpires(t *testing.T) {
+	clk := clock.NewManual(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	dog := NewDog("Max", "Golden Retriever", WithClock(clk))
+
+	dog.UseSpecialAbility()
+
+	// Still active just before the end
+	clk.Advance(59 * time.Second)
+	dog.Update(59)
+	if !dog.loyaltyActive {
+		t.Error("Loyalty should still be active after 59 seconds")
+	}
+
+	clk.Advance(2 * time.Second)
+	dog.Update(2)
+	if dog.loyaltyActive {
+		t.Error("Loyalty should expire after 60 seconds")
+	}
+	if !dog.CanUseAbility() {
+		t.Error("Dog should be able to use Loyalty again after it expires")
+	}
+}
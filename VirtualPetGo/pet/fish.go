@@ -0,0 +1,107 @@
+package pet
+
+import (
+	"fmt"
+	"time"
+)
+
+// Fish lives in a tank. Its Cleanliness is the water quality of the tank:
+// it decays, drives illness, gets fouled by food the fish doesn't eat and
+// Clean is a water change. Fish can't play.
+type Fish struct {
+	BasePet
+	filterCooldown float64
+}
+
+func NewFish(name string, opts ...Option) *Fish {
+	f := &Fish{
+		BasePet: newBasePet(name, opts...),
+	}
+	f.setup()
+	return f
+}
+
+// setup hooks the species into BasePet
+func (f *Fish) setup() {
+	f.species = f
+	f.decay.Cleanliness = FishWaterDecay
+}
+
+func (f *Fish) MakeSound() string {
+	return "Blub blub"
+}
+
+func (f *Fish) Interact() string {
+	return f.GetName() + " blows bubbles at you. " + f.MakeSound()
+}
+
+// Supports leaves out Play, a fish can only watch
+func (f *Fish) Supports(action Action) bool {
+	return action != ActionPlay
+}
+
+func (f *Fish) ActionLabel(action Action) string {
+	if action == ActionClean {
+		return "Change Water"
+	}
+	return f.BasePet.ActionLabel(action)
+}
+
+// Feed fouls the water a little, and a lot when the fish is already full
+func (f *Fish) Feed() string {
+	full := f.GetHunger() > FishFullHunger
+
+	f.setHunger(f.GetHunger() + 15)
+	f.setCleanliness(f.GetCleanliness() - FeedFouling)
+	if full {
+		f.setCleanliness(f.GetCleanliness() - OverfeedFouling)
+		return f.GetName() + " wasn't hungry, the leftover food is clouding the water!"
+	}
+	f.setHappiness(f.GetHappiness() + 5)
+	return f.GetName() + " snaps up the flakes! Hunger restored"
+}
+
+// overfeed does nothing more, the food Feed leaves in the tank is how a fish
+// pays for overfeeding
+func (f *Fish) overfeed() string {
+	return ""
+}
+
+func (f *Fish) Play() string {
+	return f.GetName() + " can't play, it swims a lap of the tank instead."
+}
+
+// Clean is a water change
+func (f *Fish) Clean() string {
+	f.setCleanliness(MaxStat)
+	f.setHappiness(f.GetHappiness() + 10)
+	return f.GetName() + " swims happily in fresh water!" + f.cureIfClean()
+}
+
+func (f *Fish) onTick(dt float64, now time.Time) {
+	f.filterCooldown = countDown(f.filterCooldown, dt)
+}
+
+func (f *Fish) UseSpecialAbility() string {
+	f.setCleanliness(f.GetCleanliness() + FilterWaterBoost)
+	f.filterCooldown = FilterCooldown
+	return f.GetName() + "'s filter runs at full power! The water clears up."
+}
+
+func (f *Fish) CanUseAbility() bool {
+	return f.filterCooldown <= 0
+}
+
+func (f *Fish) GetStatus() Status {
+	// Determine ability status text
+	abilityStatus := "(Ready!)"
+	if f.filterCooldown > 0 {
+		abilityStatus = fmt.Sprintf("(Cooldown: %.0f seconds)", f.filterCooldown)
+	}
+
+	status := f.BaseStatus("Fish")
+	status.Tank = true // Cleanliness is the water quality
+	status.SpecialAbility = "Filter Boost - Clears up the water!"
+	status.AbilityStatus = abilityStatus
+	return status
+}
@@ -0,0 +1,117 @@
+package pet
+
+import (
+	"VirtualPetGo/clock"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFishWaterFouls(t *testing.T) {
+	fish := NewFish("Bubbles", WithRand(rand.New(rand.NewPCG(1, 1))))
+
+	fish.Update(10)
+
+	// 1.5 points/sec * 0.8 water decay * 1.3 baby multiplier * 10 sec = 15.6
+	if fish.GetCleanliness() != 85 {
+		t.Errorf("Expected water quality 85, got %d", fish.GetCleanliness())
+	}
+}
+
+func TestFishOverfeedingFoulsTheWater(t *testing.T) {
+	fish := NewFish("Bubbles")
+	fish.setHunger(50)
+
+	fish.Feed()
+	if fish.GetHunger() != 65 || fish.GetCleanliness() != 100-FeedFouling {
+		t.Errorf("Expected hunger 65 and a little fouling, got hunger %d water %d", fish.GetHunger(), fish.GetCleanliness())
+	}
+
+	fish.setHunger(90)
+	fish.setCleanliness(80)
+	fish.Feed()
+	if want := 80 - FeedFouling - OverfeedFouling; fish.GetCleanliness() != want {
+		t.Errorf("Feeding a full fish should foul the water to %d, got %d", want, fish.GetCleanliness())
+	}
+}
+
+func TestOverfedFishOnlyFoulsTheWater(t *testing.T) {
+	for seed := uint64(0); seed < 20; seed++ {
+		fish := NewFish("Bubbles", WithRand(rand.New(rand.NewPCG(seed, seed))))
+		fish.setCleanliness(80)
+
+		message := Perform(fish, ActionFeed)
+
+		if want := 80 - FeedFouling - OverfeedFouling; fish.GetCleanliness() != want {
+			t.Fatalf("Expected the water fouled to %d, got %d", want, fish.GetCleanliness())
+		}
+		if fish.GetWeight() != IdealWeight || fish.IsIll() || strings.Contains(message, "already full") {
+			t.Fatalf("A full fish should not also gain weight or get a Stomach Bug, got weight %d ill %v: %q",
+				fish.GetWeight(), fish.IsIll(), message)
+		}
+	}
+}
+
+func TestFishWaterChangeCuresIllness(t *testing.T) {
+	fish := NewFish("Bubbles")
+	fish.setCleanliness(20)
+	fish.isIll = true
+	fish.illnessName = "Infection"
+
+	Perform(fish, ActionClean)
+
+	if fish.GetCleanliness() != MaxStat || fish.IsIll() {
+		t.Errorf("A water change should clear the tank and cure, got water %d ill %v", fish.GetCleanliness(), fish.IsIll())
+	}
+}
+
+func TestFishCantPlay(t *testing.T) {
+	fish := NewFish("Bubbles", WithClock(clock.NewManual(simulationStart)))
+	fish.setHunger(50)
+	before := fish.GetStatus()
+
+	message := Perform(fish, ActionPlay)
+
+	if fish.Supports(ActionPlay) || message != "A Fish can't play!" {
+		t.Errorf("Expected Play to be refused, got %q", message)
+	}
+	if fish.GetStatus() != before {
+		t.Errorf("Refused play should change nothing, before %+v after %+v", before, fish.GetStatus())
+	}
+	if fish.ActionLabel(ActionClean) != "Change Water" || NewCat("Luna", "Orange").ActionLabel(ActionClean) != "Clean" {
+		t.Error("Only the fish should call Clean a water change")
+	}
+}
+
+func TestFishFilterBoost(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	fish := NewFish("Bubbles", WithClock(clk))
+	fish.setCleanliness(40)
+
+	Perform(fish, ActionAbility)
+	if fish.GetCleanliness() != 40+FilterWaterBoost || fish.CanUseAbility() {
+		t.Errorf("Expected water %d and a cooldown, got %d", 40+FilterWaterBoost, fish.GetCleanliness())
+	}
+
+	clk.Advance(time.Duration(FilterCooldown) * time.Second)
+	fish.Update(FilterCooldown)
+	if !fish.CanUseAbility() {
+		t.Error("Filter Boost should be ready after the cooldown")
+	}
+}
+
+func TestFishRestores(t *testing.T) {
+	fish := NewFish("Bubbles")
+	fish.setCleanliness(30)
+	fish.UseSpecialAbility()
+
+	restored, err := Restore(fish.Snapshot())
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	got := restored.GetStatus()
+	if got.Type != "Fish" || !got.Tank || got.Cleanliness != 60 || got.AbilityStatus != fish.GetStatus().AbilityStatus {
+		t.Errorf("Fish not restored, got %+v", got)
+	}
+}
@@ -0,0 +1,134 @@
+package pet
+
+import "fmt"
+
+// Food is something from the pantry a pet can be fed
+type Food struct {
+	Name        string
+	Description string
+	Nutrition   int // Hunger restored
+	Happiness   int
+}
+
+// foods in the order the pantry lists them. Kibble is the standard meal,
+// other foods change a meal by how they differ from it.
+var foods = []Food{
+	{Name: "Kibble", Description: "Plain and filling", Nutrition: MealNutrition, Happiness: MealHappiness},
+	{Name: "Tuna", Description: "Rich and smelly", Nutrition: 25, Happiness: 10},
+	{Name: "Seeds", Description: "Light and crunchy", Nutrition: 15, Happiness: 5},
+	{Name: "Treats", Description: "Barely filling, hugely popular", Nutrition: 5, Happiness: 20},
+}
+
+// Taste is how a pet feels about a food
+type Taste string
+
+const (
+	Likes   Taste = "Likes"
+	Loves   Taste = "Loves"
+	Refuses Taste = "Refuses"
+)
+
+// Tastes are the foods a species loves and the ones it won't eat,
+// everything else it likes
+type Tastes struct {
+	Loves   []string `json:"loves"`
+	Refuses []string `json:"refuses"`
+}
+
+// tastes of the built in species, data driven species bring their own
+var tastes = map[string]Tastes{
+	"Dog":  {Loves: []string{"Kibble", "Treats"}, Refuses: []string{"Seeds"}},
+	"Cat":  {Loves: []string{"Tuna"}, Refuses: []string{"Seeds"}},
+	"Bird": {Loves: []string{"Seeds"}, Refuses: []string{"Tuna", "Kibble"}},
+	"Fish": {Loves: []string{"Seeds"}, Refuses: []string{"Tuna"}},
+}
+
+// starterPantry is what every pet starts out with
+var starterPantry = map[string]int{"Kibble": 10, "Tuna": 3, "Seeds": 10, "Treats": 5}
+
+// Foods returns every food in pantry order
+func Foods() []Food {
+	return append([]Food(nil), foods...)
+}
+
+// StarterPantry returns a copy of the pantry new pets get
+func StarterPantry() map[string]int {
+	return copyStock(starterPantry)
+}
+
+func foodNamed(name string) (Food, bool) {
+	for _, f := range foods {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Food{}, false
+}
+
+// TasteOf returns how the pet feels about the named food
+func TasteOf(p Pet, food string) Taste {
+	t := tastes[p.GetStatus().Type]
+	if g, ok := p.(*Generic); ok {
+		t = g.def.Tastes
+	}
+	return t.of(food)
+}
+
+func (t Tastes) of(food string) Taste {
+	for _, name := range t.Refuses {
+		if name == food {
+			return Refuses
+		}
+	}
+	for _, name := range t.Loves {
+		if name == food {
+			return Loves
+		}
+	}
+	return Likes
+}
+
+// Pantry returns how much of each food is left
+func (bp *BasePet) Pantry() map[string]int {
+	return copyStock(bp.pantry)
+}
+
+// serve checks that there is some of the food left and the pet will eat it,
+// it returns why not
+func (bp *BasePet) serve(p Pet, name string) (Food, string) {
+	food, ok := foodNamed(name)
+	if !ok {
+		return Food{}, fmt.Sprintf("There is no such food as %s.", name)
+	}
+	if bp.pantry[name] <= 0 {
+		return Food{}, fmt.Sprintf("There's no %s left in the pantry.", name)
+	}
+	if TasteOf(p, name) == Refuses {
+		species := p.GetStatus().Type
+		return Food{}, fmt.Sprintf("%s won't touch the %s, a %s doesn't eat that!", bp.GetName(), name, species)
+	}
+	return food, ""
+}
+
+// eat uses up the food after the meal and adds how it differs from a standard meal
+func (bp *BasePet) eat(p Pet, food Food) string {
+	bp.pantry[food.Name]--
+	bp.setHunger(bp.GetHunger() + food.Nutrition - MealNutrition)
+	bp.setHappiness(bp.GetHappiness() + food.Happiness - MealHappiness)
+
+	message := fmt.Sprintf(" (%s, %d left)", food.Name, bp.pantry[food.Name])
+	if TasteOf(p, food.Name) == Loves {
+		bp.setHappiness(bp.GetHappiness() + LovedFoodHappiness)
+		message += fmt.Sprintf(" %s loves %s!", bp.GetName(), food.Name)
+	}
+	return message
+}
+
+// copyStock copies a pantry or supplies
+func copyStock(from map[string]int) map[string]int {
+	stock := make(map[string]int, len(from))
+	for name, count := range from {
+		stock[name] = count
+	}
+	return stock
+}
@@ -0,0 +1,87 @@
+package pet
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestFoodsChangeTheMeal(t *testing.T) {
+	dog := NewDog("Max", "Golden Retriever")
+	dog.setHunger(40)
+	dog.setHappiness(50)
+
+	message := PerformWith(dog, ActionFeed, "Treats")
+	// A treat is barely filling, but the dog loves it
+	if dog.GetHunger() != 45 || dog.GetHappiness() != 50+20+LovedFoodHappiness {
+		t.Errorf("Expected hunger 45 and happiness %d, got %d and %d", 50+20+LovedFoodHappiness, dog.GetHunger(), dog.GetHappiness())
+	}
+	if !strings.Contains(message, "Max loves Treats!") || dog.Pantry()["Treats"] != 4 {
+		t.Errorf("Expected a loved treat taken from the pantry, got %q and %v", message, dog.Pantry())
+	}
+}
+
+func TestBirdRefusesTuna(t *testing.T) {
+	bird := NewBird("Tweety", "Canary")
+	Perform(bird, ActionSleep)
+	bird.setHunger(40)
+
+	message := PerformWith(bird, ActionFeed, "Tuna")
+	if !strings.Contains(message, "won't touch the Tuna") {
+		t.Errorf("Expected the bird to refuse tuna, got %q", message)
+	}
+	if bird.GetHunger() != 40 || bird.Pantry()["Tuna"] != 3 || bird.SleepState() != Asleep {
+		t.Errorf("A refused food should change nothing, got hunger %d, %d tuna, %s",
+			bird.GetHunger(), bird.Pantry()["Tuna"], bird.SleepState())
+	}
+	if TasteOf(NewCat("Whiskers", "Orange"), "Tuna") != Loves {
+		t.Error("Cats should love tuna")
+	}
+}
+
+func TestFoodRunsOut(t *testing.T) {
+	cat := NewCat("Whiskers", "Orange", WithRand(rand.New(rand.NewPCG(1, 1))))
+	for i := 0; i < 3; i++ {
+		PerformWith(cat, ActionFeed, "Tuna")
+	}
+	if message := PerformWith(cat, ActionFeed, "Tuna"); !strings.Contains(message, "no Tuna left") {
+		t.Errorf("Expected the tuna to run out, got %q", message)
+	}
+
+	// Feed on its own is a standard meal and needs no stock
+	Perform(cat, ActionFeed)
+	if cat.Pantry()["Kibble"] != 10 {
+		t.Errorf("A standard meal should not use the pantry, got %v", cat.Pantry())
+	}
+}
+
+func TestDataDrivenSpeciesHaveTastes(t *testing.T) {
+	hamster, err := NewPet("Hamster", "Nibbles")
+	if err != nil {
+		t.Fatalf("NewPet failed: %v", err)
+	}
+	if TasteOf(hamster, "Seeds") != Loves || TasteOf(hamster, "Tuna") != Refuses || TasteOf(hamster, "Kibble") != Likes {
+		t.Error("Expected the hamster's tastes from its definition")
+	}
+}
+
+func TestPantryIsKeptOnRestore(t *testing.T) {
+	cat := NewCat("Whiskers", "Orange")
+	PerformWith(cat, ActionFeed, "Tuna")
+
+	restored, err := Restore(cat.Snapshot())
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if restored.Pantry()["Tuna"] != 2 {
+		t.Errorf("Expected 2 tuna left, got %v", restored.Pantry())
+	}
+
+	// Pets from before the pantry get the starter one
+	s := cat.Snapshot()
+	s.Pantry = nil
+	restored, _ = Restore(s)
+	if restored.Pantry()["Tuna"] != 3 {
+		t.Errorf("Expected the starter pantry, got %v", restored.Pantry())
+	}
+}
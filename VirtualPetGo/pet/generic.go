@@ -0,0 +1,164 @@
+package pet
+
+import (
+	"fmt"
+	"time"
+)
+
+// Generic is a pet of a species defined in data, see SpeciesDef
+type Generic struct {
+	BasePet
+	def *SpeciesDef
+
+	cooldown      float64 // Seconds before the ability can be used again
+	activeFor     float64 // Seconds the ability stays active
+	usesRemaining int     // Only counted when the ability has limited uses
+	timesUsed     int
+}
+
+// NewPet creates a pet of a data driven species
+func NewPet(speciesName, name string, opts ...Option) (*Generic, error) {
+	def, ok := Species(speciesName)
+	if !ok {
+		return nil, fmt.Errorf("unknown species %q", speciesName)
+	}
+	g := &Generic{
+		BasePet:       newBasePet(name, opts...),
+		def:           def,
+		usesRemaining: def.Ability.Uses,
+	}
+	g.setup()
+	return g, nil
+}
+
+// setup hooks the species into BasePet
+func (g *Generic) setup() {
+	g.species = g
+	g.decay = g.def.Decay
+}
+
+// Species returns the definition the pet plays
+func (g *Generic) Species() *SpeciesDef {
+	return g.def
+}
+
+func (g *Generic) MakeSound() string {
+	return g.def.Sound
+}
+
+func (g *Generic) Interact() string {
+	return g.def.Text(g.def.Interact, g.GetName())
+}
+
+func (g *Generic) Feed() string {
+	return g.perform(ActionFeed, g.BasePet.Feed)
+}
+
+func (g *Generic) Play() string {
+	return g.perform(ActionPlay, g.BasePet.Play)
+}
+
+func (g *Generic) Sleep() string {
+	return g.perform(ActionSleep, g.BasePet.Sleep)
+}
+
+func (g *Generic) Clean() string {
+	effect, ok := g.def.Actions[ActionClean]
+	if !ok {
+		return g.BasePet.Clean()
+	}
+	return g.apply(effect) + g.cureIfClean()
+}
+
+// perform applies the species' effect for the action, or does what BasePet does
+func (g *Generic) perform(action Action, base func() string) string {
+	if effect, ok := g.def.Actions[action]; ok {
+		return g.apply(effect)
+	}
+	return base()
+}
+
+// apply changes the stats by an effect and returns its message
+func (g *Generic) apply(effect Effect) string {
+	g.setHealth(g.GetHealth() + effect.Health)
+	g.setHunger(g.GetHunger() + effect.Hunger)
+	g.setHappiness(g.GetHappiness() + effect.Happiness)
+	g.setCleanliness(g.GetCleanliness() + effect.Cleanliness)
+	return g.def.Text(effect.Message, g.GetName())
+}
+
+func (g *Generic) getHappinessDecayModifier() float64 {
+	if g.activeFor > 0 {
+		return g.def.Ability.HappinessDecay
+	}
+	return 1.0
+}
+
+func (g *Generic) onTick(dt float64, now time.Time) {
+	g.cooldown = countDown(g.cooldown, dt)
+	g.activeFor = countDown(g.activeFor, dt)
+
+	if !g.IsAlive() && g.def.Ability.Revive && g.usesRemaining > 0 {
+		g.setHealth(100)
+		g.usesRemaining--
+		g.notify(fmt.Sprintf("✨ %s used %s! %d uses remaining.", g.GetName(), g.def.Ability.Name, g.usesRemaining))
+	}
+}
+
+// countDown takes dt off a timer in seconds. What is left from adding up
+// the ticks in floating point counts as run out.
+func countDown(seconds, dt float64) float64 {
+	if seconds -= dt; seconds < 1e-9 {
+		return 0
+	}
+	return seconds
+}
+
+func (g *Generic) UseSpecialAbility() string {
+	ability := g.def.Ability
+	message := g.apply(Effect{
+		Health:      ability.Effect.Health,
+		Hunger:      ability.Effect.Hunger,
+		Happiness:   ability.Effect.Happiness,
+		Cleanliness: ability.Effect.Cleanliness,
+		Message:     ability.Message,
+	})
+
+	g.cooldown = ability.Cooldown
+	g.activeFor = ability.Duration
+	if ability.Uses > 0 {
+		g.usesRemaining--
+	}
+	g.timesUsed++
+	return message
+}
+
+func (g *Generic) CanUseAbility() bool {
+	if g.def.Ability.Uses > 0 && g.usesRemaining <= 0 {
+		return false
+	}
+	return g.cooldown <= 0 && g.activeFor <= 0
+}
+
+func (g *Generic) GetStatus() Status {
+	// Determine ability status text
+	abilityStatus := "(Ready!)"
+	switch {
+	case g.activeFor > 0:
+		abilityStatus = fmt.Sprintf("(Active: %.0f seconds)", g.activeFor)
+	case g.def.Ability.Uses > 0:
+		abilityStatus = fmt.Sprintf("(%d uses remaining)", g.usesRemaining)
+	case g.cooldown > 0:
+		abilityStatus = fmt.Sprintf("(Cooldown: %.0f seconds)", g.cooldown)
+	}
+
+	specialAbility := g.def.Ability.Name
+	if g.def.Ability.Description != "" {
+		specialAbility += " - " + g.def.Ability.Description
+	}
+
+	status := g.BaseStatus(g.def.Name)
+	status.SpecialAbility = specialAbility
+	status.AbilityStatus = abilityStatus
+	return status
+}
@@ -0,0 +1,223 @@
+package pet
+
+import (
+	"VirtualPetGo/clock"
+	"encoding/json"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeSpecies puts definitions in a temp folder for LoadSpecies
+func writeSpecies(t *testing.T, defs ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for i, def := range defs {
+		path := filepath.Join(dir, string(rune('a'+i))+".json")
+		if err := os.WriteFile(path, []byte(def), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// forgetSpecies removes species a test loaded
+func forgetSpecies(t *testing.T, names ...string) {
+	t.Cleanup(func() {
+		speciesMu.Lock()
+		defer speciesMu.Unlock()
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		for _, name := range names {
+			delete(species, name)
+			registry = slices.DeleteFunc(registry, func(d Descriptor) bool { return d.Name == name })
+		}
+	})
+}
+
+func TestHamsterIsBuiltIn(t *testing.T) {
+	hamster, err := NewPet("Hamster", "Nibbles")
+	if err != nil {
+		t.Fatalf("NewPet failed: %v", err)
+	}
+
+	hamster.setHunger(50)
+	message := hamster.Feed()
+	if hamster.GetHunger() != 75 || !strings.Contains(message, "Nibbles fills its cheek pouches") {
+		t.Errorf("Expected the hamster's feed effect, got hunger %d and %q", hamster.GetHunger(), message)
+	}
+
+	// Sleep has no effect of its own, it does what every pet does
+	hamster.setHealth(50)
+	hamster.Sleep()
+	if hamster.GetHealth() != 70 {
+		t.Errorf("Expected the normal sleep, got health %d", hamster.GetHealth())
+	}
+
+	if got := hamster.Interact(); got != "Nibbles stuffs its cheeks and goes Squeak!" {
+		t.Errorf("Unexpected interact text %q", got)
+	}
+	if status := hamster.GetStatus(); status.Type != "Hamster" || !strings.HasPrefix(status.SpecialAbility, "Wheel Sprint") {
+		t.Errorf("Unexpected status %+v", status)
+	}
+}
+
+func TestSpeciesDecayMultipliers(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	hamster, _ := NewPet("Hamster", "Nibbles", WithClock(clk))
+	dog := NewDog("Max", "Golden Retriever", WithClock(clk))
+
+	clk.Advance(10 * time.Second)
+	hamster.Update(10)
+	dog.Update(10)
+
+	// 2 points/sec * 10 sec * 1.3 baby multiplier = 26, times 1.4 for a hamster
+	if dog.GetHunger() != 74 || hamster.GetHunger() != 64 {
+		t.Errorf("Expected hunger 74 for the dog and 64 for the hamster, got %d and %d",
+			dog.GetHunger(), hamster.GetHunger())
+	}
+}
+
+func TestDefinedSpeciesKeepsMissingDecayRates(t *testing.T) {
+	forgetSpecies(t, "Frog")
+	err := DefineSpecies(SpeciesDef{Name: "Frog", Decay: Decay{Hunger: 1.4}, Ability: AbilityDef{Name: "Croak"}})
+	if err != nil {
+		t.Fatalf("DefineSpecies failed: %v", err)
+	}
+	def, _ := Species("Frog")
+	if want := (Decay{Hunger: 1.4, Cleanliness: 1, Happiness: 1, Health: 1}); def.Decay != want {
+		t.Errorf("Expected the rates left out to be 1, got %+v", def.Decay)
+	}
+}
+
+func TestActiveAbilityKeepsHappinessDecay(t *testing.T) {
+	forgetSpecies(t, "Frog")
+	err := DefineSpecies(SpeciesDef{Name: "Frog", Ability: AbilityDef{Name: "Croak", Duration: 30}})
+	if err != nil {
+		t.Fatalf("DefineSpecies failed: %v", err)
+	}
+	clk := clock.NewManual(simulationStart)
+	frog, _ := NewPet("Frog", "Hoppy", WithClock(clk), WithRand(rand.New(rand.NewPCG(1, 1))))
+
+	frog.setHunger(10) // Happiness only decays for a hungry or dirty pet
+	frog.UseSpecialAbility()
+	clk.Advance(10 * time.Second)
+	frog.Update(10)
+	if frog.GetHappiness() == MaxStat {
+		t.Error("An ability without a happiness decay should leave it as it is, not stop it")
+	}
+}
+
+func TestAbilityCooldown(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	hamster, _ := NewPet("Hamster", "Nibbles", WithClock(clk))
+
+	hamster.setHappiness(50)
+	hamster.UseSpecialAbility()
+	if hamster.GetHappiness() != 80 || hamster.CanUseAbility() {
+		t.Errorf("Expected happiness 80 and a cooldown, got %d", hamster.GetHappiness())
+	}
+
+	clk.Advance(90 * time.Second)
+	hamster.Update(90)
+	if !hamster.CanUseAbility() {
+		t.Error("Ability should be ready after the 90 second cooldown")
+	}
+}
+
+func TestLoadedSpeciesAbilities(t *testing.T) {
+	dir := writeSpecies(t, `{
+		"name": "Turtle",
+		"blurb": "Slow and steady",
+		"sound": "...",
+		"interact": "{name} blinks slowly",
+		"decay": { "hunger": 0.5 },
+		"ability": {
+			"name": "Shell",
+			"message": "{name} hides in its shell",
+			"effect": { "health": 100 },
+			"duration": 30,
+			"happiness_decay": 0.25,
+			"uses": 2,
+			"revive": true
+		}
+	}`)
+	forgetSpecies(t, "Turtle")
+	if err := LoadSpecies(dir); err != nil {
+		t.Fatalf("LoadSpecies failed: %v", err)
+	}
+
+	clk := clock.NewManual(simulationStart)
+	turtle, err := NewPet("Turtle", "Shelly", WithClock(clk))
+	if err != nil {
+		t.Fatalf("NewPet failed: %v", err)
+	}
+
+	turtle.UseSpecialAbility()
+	if turtle.getHappinessDecayModifier() != 0.25 || turtle.CanUseAbility() {
+		t.Error("Ability should be active for 30 seconds")
+	}
+	clk.Advance(30 * time.Second)
+	turtle.Update(30)
+	if turtle.getHappinessDecayModifier() != 1 || !turtle.CanUseAbility() {
+		t.Error("Ability should have worn off")
+	}
+
+	// The last use brings it back when it dies
+	turtle.setHealth(0)
+	clk.Advance(time.Second)
+	turtle.Update(1)
+	if !turtle.IsAlive() || turtle.usesRemaining != 0 || turtle.CanUseAbility() {
+		t.Errorf("Expected a revive with the last use, got health %d and %d uses", turtle.GetHealth(), turtle.usesRemaining)
+	}
+	if events := turtle.TakeEvents(); len(events) != 1 {
+		t.Errorf("Expected one revive event, got %v", events)
+	}
+}
+
+func TestGenericSnapshotRoundTrip(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	hamster, _ := NewPet("Hamster", "Nibbles", WithClock(clk), WithRand(rand.New(rand.NewPCG(2, 2))))
+	hamster.UseSpecialAbility()
+	clk.Advance(12 * time.Second)
+	hamster.Update(12)
+
+	restored, err := Restore(hamster.Snapshot(), WithClock(clk))
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	want, _ := json.Marshal(hamster.Snapshot())
+	got, _ := json.Marshal(restored.Snapshot())
+	if string(got) != string(want) {
+		t.Errorf("Snapshot changed on restore:\nwant %s\ngot  %s", want, got)
+	}
+	if restored.GetStatus() != hamster.GetStatus() {
+		t.Errorf("Status changed on restore")
+	}
+}
+
+func TestBadSpeciesAreRefused(t *testing.T) {
+	ability := `"ability": { "name": "Nap" }`
+	for name, def := range map[string]string{
+		"no name":         `{` + ability + `}`,
+		"built in name":   `{ "name": "Dog", ` + ability + ` }`,
+		"defined twice":   `{ "name": "Hamster", ` + ability + ` }`,
+		"unknown action":  `{ "name": "Frog", "actions": { "ability": {} }, ` + ability + ` }`,
+		"negative decay":  `{ "name": "Frog", "decay": { "hunger": -1 }, ` + ability + ` }`,
+		"unnamed ability": `{ "name": "Frog", "ability": {} }`,
+		"endless revive":  `{ "name": "Frog", "ability": { "name": "Croak", "revive": true } }`,
+		"unknown food":    `{ "name": "Frog", "tastes": { "loves": ["Flies"] }, ` + ability + ` }`,
+		"not json":        `{ "name": `,
+	} {
+		t.Run(name, func(t *testing.T) {
+			forgetSpecies(t, "Frog")
+			if err := LoadSpecies(writeSpecies(t, def)); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
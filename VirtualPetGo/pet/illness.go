@@ -0,0 +1,200 @@
+package pet
+
+import "fmt"
+
+// Severity is how bad an illness has got
+type Severity string
+
+const (
+	Mild     Severity = "Mild"
+	Moderate Severity = "Moderate"
+	Severe   Severity = "Severe"
+)
+
+// severities in the order an illness goes through them, with what they
+// multiply the symptoms by
+var severities = []struct {
+	Severity Severity
+	Factor   float64
+}{
+	{Mild, 1},
+	{Moderate, 1.5},
+	{Severe, 2.5},
+}
+
+// Symptoms are the stat points an illness takes per second at the Mild stage
+type Symptoms struct {
+	Health      float64
+	Cleanliness float64
+	Happiness   float64
+	Energy      float64 // Only while awake, a sleeping pet rests it off
+}
+
+// Illness is something a pet can catch, with what it does and what cures it
+type Illness struct {
+	Name        string
+	Description string
+	Symptoms    Symptoms
+	Blocks      map[Action]string // Actions the illness stops, with why
+	Worsens     float64           // seconds at each stage before it gets worse
+
+	// Cures, any one of them works
+	Medicine string  // Shop medicine that cures it
+	Rest     bool    // Waking up from a full sleep
+	Clean    bool    // Cleaning the pet (a water change for a fish) above CureCleanliness
+	Passes   float64 // seconds until it goes away on its own, 0 if it doesn't
+}
+
+// illnesses pets can catch, in the order they are listed
+var illnesses = []Illness{
+	{
+		Name:        "Cold",
+		Description: "Sniffles and sneezes, a little gloomy",
+		Symptoms:    Symptoms{Health: 0.8, Happiness: 0.5},
+		Worsens:     60,
+		Medicine:    "Cold Syrup",
+		Rest:        true,
+		Passes:      90,
+	},
+	{
+		Name:        "Fleas",
+		Description: "Itchy, scratchy and getting grubby",
+		Symptoms:    Symptoms{Health: 0.5, Cleanliness: 1, Happiness: 0.5},
+		Worsens:     45,
+		Medicine:    "Flea Drops",
+		Clean:       true,
+	},
+	{
+		Name:        "Stomach Bug",
+		Description: "Can't keep any food down",
+		Symptoms:    Symptoms{Health: 1},
+		Blocks:      map[Action]string{ActionFeed: "can't keep any food down"},
+		Worsens:     40,
+		Medicine:    "Tummy Tablets",
+		Passes:      60,
+	},
+	{
+		Name:        "Fever",
+		Description: "Hot and worn out",
+		Symptoms:    Symptoms{Health: 1, Energy: 1.5},
+		Worsens:     40,
+		Medicine:    "Fever Reducer",
+		Rest:        true,
+	},
+	{
+		Name:        "Infection",
+		Description: "A nasty infection that gets worse fast",
+		Symptoms:    Symptoms{Health: 1.2},
+		Worsens:     30,
+		Medicine:    "Antibiotics",
+		Clean:       true,
+	},
+}
+
+// unnamedIllness is an illness without a name, like the ones in saves from
+// the C# game. It works like illnesses did before the catalogue.
+var unnamedIllness = Illness{
+	Symptoms: Symptoms{Health: 1},
+	Clean:    true,
+}
+
+// Illnesses returns every illness a pet can catch
+func Illnesses() []Illness {
+	return append([]Illness(nil), illnesses...)
+}
+
+// IllnessNamed looks an illness up by name
+func IllnessNamed(name string) (Illness, bool) {
+	for _, i := range illnesses {
+		if i.Name == name {
+			return i, true
+		}
+	}
+	return Illness{}, false
+}
+
+// illness is what the pet is ill with, unknown names work like an unnamed illness
+func (bp *BasePet) illness() Illness {
+	if i, ok := IllnessNamed(bp.illnessName); ok {
+		return i
+	}
+	return unnamedIllness
+}
+
+// fallIll makes the pet ill with the named illness, starting out mild
+func (bp *BasePet) fallIll(name string) {
+	bp.isIll = true
+	bp.illnessName = name
+	bp.sickFor = 0
+}
+
+// IllnessStage returns how bad the illness has got, empty when the pet is well
+func (bp *BasePet) IllnessStage() Severity {
+	if !bp.isIll {
+		return ""
+	}
+	return severities[bp.stage()].Severity
+}
+
+// stage is the index of the illness' severity
+func (bp *BasePet) stage() int {
+	worsens := bp.illness().Worsens
+	if worsens <= 0 {
+		return 0
+	}
+	return min(int(bp.sickFor/worsens), len(severities)-1)
+}
+
+// suffer applies the symptoms for dt seconds, worsens the illness over time
+// and lets it pass when it goes away on its own
+func (bp *BasePet) suffer(dt, multiplier, healthDecay float64) {
+	illness := bp.illness()
+	symptoms := illness.Symptoms
+	factor := severities[bp.stage()].Factor * multiplier
+
+	bp.setHealth(bp.GetHealth() - drain(&bp.sim.health, symptoms.Health*dt*factor*healthDecay))
+	bp.setCleanliness(bp.GetCleanliness() - drain(&bp.sim.cleanliness, symptoms.Cleanliness*dt*factor))
+	bp.setHappiness(bp.GetHappiness() - drain(&bp.sim.happiness, symptoms.Happiness*dt*factor))
+	if bp.asleepFor == 0 {
+		bp.setEnergy(bp.GetEnergy() - drain(&bp.sim.energy, symptoms.Energy*dt*factor))
+	}
+
+	before := bp.stage()
+	bp.sickFor += dt
+	if illness.Passes > 0 && bp.sickFor >= illness.Passes {
+		bp.recoverFromIllness()
+		bp.notify(fmt.Sprintf("😊 %s got over the %s on their own!", bp.GetName(), illness.Name))
+		return
+	}
+	if stage := bp.stage(); stage > before {
+		bp.notify(fmt.Sprintf("🤒 %s's %s got worse, it's %s now!", bp.GetName(), bp.illnessTitle(), severities[stage].Severity))
+	}
+}
+
+// illnessTitle names the illness in messages
+func (bp *BasePet) illnessTitle() string {
+	if bp.illnessName == "" {
+		return "illness"
+	}
+	return bp.illnessName
+}
+
+// illnessRefusal says why the illness stops the action, empty if it doesn't
+func (bp *BasePet) illnessRefusal(action Action) string {
+	if !bp.isIll {
+		return ""
+	}
+	if why, ok := bp.illness().Blocks[action]; ok {
+		return fmt.Sprintf("%s has a %s and %s.", bp.GetName(), bp.illnessTitle(), why)
+	}
+	return ""
+}
+
+// restCure cures an illness that rest cures, after a full sleep
+func (bp *BasePet) restCure() {
+	if bp.isIll && bp.illness().Rest {
+		name := bp.illnessTitle()
+		bp.recoverFromIllness()
+		bp.notify(fmt.Sprintf("💤 %s slept off the %s!", bp.GetName(), name))
+	}
+}
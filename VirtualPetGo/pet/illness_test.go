@@ -0,0 +1,139 @@
+package pet
+
+import (
+	"VirtualPetGo/clock"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"time"
+)
+
+// illPet returns an adult dog that won't catch anything else, ill with the named illness
+func illPet(t *testing.T, illness string) (*Dog, *clock.Manual) {
+	t.Helper()
+	clk := clock.NewManual(simulationStart)
+	dog := NewDog("Max", "Golden Retriever", WithClock(clk), WithRand(rand.New(rand.NewPCG(1, 1))))
+	dog.birthTime = simulationStart.Add(-10 * time.Minute) // Adult, no age multiplier
+	dog.fallIll(illness)
+	return dog, clk
+}
+
+func TestEveryIllnessHasAMedicineInTheShop(t *testing.T) {
+	for _, illness := range Illnesses() {
+		item, ok := shopItem(illness.Medicine)
+		if !ok || item.Kind != MedicineItem {
+			t.Errorf("%s should be curable with a medicine from the shop, got %q", illness.Name, illness.Medicine)
+		}
+	}
+}
+
+func TestIllnessesHaveTheirOwnSymptoms(t *testing.T) {
+	fleas, clk := illPet(t, "Fleas")
+	fever, _ := illPet(t, "Fever")
+	fever.clock = clk
+	clk.Advance(10 * time.Second)
+	fleas.Update(10)
+	fever.Update(10)
+
+	// 1.5 cleanliness decay plus 1 from fleas, every second
+	if fleas.GetCleanliness() != 75 {
+		t.Errorf("Fleas should make the pet dirty faster, got cleanliness %d", fleas.GetCleanliness())
+	}
+	// 0.25 energy decay plus 1.5 from the fever, every second
+	if fever.GetEnergy() != 83 {
+		t.Errorf("A fever should wear the pet out, got energy %d", fever.GetEnergy())
+	}
+}
+
+func TestIllnessWorsensOverTime(t *testing.T) {
+	dog, clk := illPet(t, "Infection")
+	if dog.GetStatus().IllnessStage != Mild {
+		t.Fatalf("A new illness should be mild, got %s", dog.GetStatus().IllnessStage)
+	}
+
+	clk.Advance(30 * time.Second)
+	dog.Update(30)
+	if dog.GetStatus().IllnessStage != Moderate {
+		t.Errorf("Expected the infection to be moderate after 30s, got %s", dog.GetStatus().IllnessStage)
+	}
+	if events := strings.Join(dog.TakeEvents(), "\n"); !strings.Contains(events, "Infection got worse, it's Moderate") {
+		t.Errorf("Expected a worsening event, got %q", events)
+	}
+
+	// Health drops faster at each stage
+	health := dog.GetHealth()
+	clk.Advance(10 * time.Second)
+	dog.Update(10)
+	if lost := health - dog.GetHealth(); lost < 17 {
+		t.Errorf("A moderate infection should take about 1.5 times 1.2 health a second, lost %d in 10s", lost)
+	}
+
+	clk.Advance(time.Minute)
+	dog.Update(60)
+	if dog.GetStatus().IllnessStage != Severe {
+		t.Errorf("Expected the infection to end up severe, got %s", dog.GetStatus().IllnessStage)
+	}
+}
+
+func TestStomachBugStopsFeedingAndPasses(t *testing.T) {
+	dog, clk := illPet(t, "Stomach Bug")
+	dog.setHunger(50)
+
+	if message := Perform(dog, ActionFeed); !strings.Contains(message, "can't keep any food down") {
+		t.Errorf("Expected a stomach bug to stop feeding, got %q", message)
+	}
+	if dog.GetHunger() != 50 {
+		t.Errorf("A refused meal should change nothing, got hunger %d", dog.GetHunger())
+	}
+
+	clk.Advance(time.Minute)
+	dog.Update(60)
+	if dog.IsIll() {
+		t.Error("A stomach bug should pass on its own")
+	}
+	if events := strings.Join(dog.TakeEvents(), "\n"); !strings.Contains(events, "got over the Stomach Bug") {
+		t.Errorf("Expected a recovery event, got %q", events)
+	}
+}
+
+func TestIllnessesHaveTheirOwnCures(t *testing.T) {
+	cold, _ := illPet(t, "Cold")
+	cold.setCleanliness(20)
+	Perform(cold, ActionClean)
+	if !cold.IsIll() {
+		t.Error("Cleaning should not cure a Cold")
+	}
+
+	fleas, _ := illPet(t, "Fleas")
+	fleas.setCleanliness(30)
+	if message := Perform(fleas, ActionClean); fleas.IsIll() || !strings.Contains(message, "Fleas has been cured") {
+		t.Errorf("Cleaning should get rid of fleas, got %q", message)
+	}
+
+	fever, clk := illPet(t, "Fever")
+	fever.setHunger(40) // Not too full to sleep
+	Perform(fever, ActionSleep)
+	clk.Advance(time.Minute)
+	fever.Update(60)
+	if fever.IsIll() {
+		t.Error("A full sleep should break a fever")
+	}
+	if events := strings.Join(fever.TakeEvents(), "\n"); !strings.Contains(events, "slept off the Fever") {
+		t.Errorf("Expected a rest cure event, got %q", events)
+	}
+}
+
+func TestUnnamedIllnessWorksLikeBefore(t *testing.T) {
+	dog, _ := illPet(t, "")
+	dog.supplies = map[string]int{"Flea Drops": 1}
+	if message := PerformWith(dog, ActionMedicine, "Flea Drops"); dog.IsIll() {
+		t.Errorf("Any medicine should cure an unnamed illness, got %q", message)
+	}
+
+	dog.fallIll("")
+	dog.setCleanliness(30)
+	Perform(dog, ActionClean)
+	if dog.IsIll() {
+		t.Error("Cleaning should cure an unnamed illness")
+	}
+}
@@ -0,0 +1,99 @@
+package pet
+
+import (
+	"VirtualPetGo/clock"
+	"time"
+)
+
+// OfflineReport describes what happened to a pet while the game was closed
+type OfflineReport struct {
+	Away      time.Duration // Wall-clock time since the last save
+	Simulated time.Duration // Part of Away that was applied (capped)
+
+	Before Status
+	After  Status
+
+	Illnesses []string // Illnesses caught while away, in order
+	LivesUsed int      // Cat lives or revives spent while away
+	Died      bool
+}
+
+// SimulateOffline applies the time spent away to the pet through Update.
+// clk must be the pet's clock, set to the save time, it is moved forward
+// with each step so aging and ability timers follow along.
+// At most limit is simulated, a limit of 0 means no cap.
+func SimulateOffline(p Pet, clk *clock.Manual, away, limit time.Duration) OfflineReport {
+	report := OfflineReport{
+		Away:      away,
+		Simulated: away,
+		Before:    p.GetStatus(),
+	}
+	if limit > 0 && report.Simulated > limit {
+		report.Simulated = limit
+	}
+	if report.Simulated < 0 {
+		report.Simulated = 0
+	}
+
+	livesBefore := livesOf(p)
+	base, ok := baseOf(p)
+	var played time.Duration
+	if ok {
+		played = base.activeTime
+	}
+	wasIll := p.IsIll()
+
+	start := clk.Now()
+
+	// Step in illness check sized chunks so illness rolls happen at the normal rate
+	remaining := report.Simulated.Seconds()
+	for remaining > 0 && p.IsAlive() {
+		step := IllnessCheckInterval
+		if remaining < step {
+			step = remaining
+		}
+		clk.Advance(time.Duration(step * float64(time.Second)))
+		p.Update(step)
+		remaining -= step
+
+		if p.IsIll() && !wasIll {
+			report.Illnesses = append(report.Illnesses, p.GetIllness())
+		}
+		wasIll = p.IsIll()
+	}
+	// The report already covers what happened
+	p.TakeEvents()
+	// Time away isn't time played
+	if ok {
+		base.activeTime = played
+	}
+
+	// A dead pet keeps aging, so the clock always ends at the full simulated time
+	clk.Set(start.Add(report.Simulated))
+
+	report.After = p.GetStatus()
+	report.LivesUsed = livesBefore - livesOf(p)
+	report.Died = !p.IsAlive()
+	return report
+}
+
+// baseOf returns the BasePet every species embeds
+func baseOf(p Pet) (*BasePet, bool) {
+	b, ok := p.(interface{ base() *BasePet })
+	if !ok {
+		return nil, false
+	}
+	return b.base(), true
+}
+
+// livesOf returns the Nine Lives counter for cats and the uses left of a
+// reviving ability, 0 for other species
+func livesOf(p Pet) int {
+	if cat, ok := p.(*Cat); ok {
+		return cat.livesRemaining
+	}
+	if g, ok := p.(*Generic); ok && g.def.Ability.Revive {
+		return g.usesRemaining
+	}
+	return 0
+}
@@ -0,0 +1,85 @@
+package pet
+
+import (
+	"VirtualPetGo/clock"
+	"testing"
+	"time"
+)
+
+var offlineStart = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestSimulateOfflineAppliesDecay(t *testing.T) {
+	clk := clock.NewManual(offlineStart)
+	dog := NewDog("Max", "Golden Retriever", WithClock(clk))
+
+	report := SimulateOffline(dog, clk, 10*time.Second, 0)
+
+	if report.Before.Hunger != 100 {
+		t.Errorf("Expected hunger 100 before, got %d", report.Before.Hunger)
+	}
+	if report.After.Hunger >= report.Before.Hunger {
+		t.Errorf("Hunger should decay while away, was %d, now %d", report.Before.Hunger, report.After.Hunger)
+	}
+	if report.After.Hunger != dog.GetHunger() {
+		t.Errorf("Report should match the pet, report %d, pet %d", report.After.Hunger, dog.GetHunger())
+	}
+}
+
+func TestSimulateOfflineRespectsCap(t *testing.T) {
+	clk := clock.NewManual(offlineStart)
+	dog := NewDog("Max", "Golden Retriever", WithClock(clk))
+
+	report := SimulateOffline(dog, clk, 10*time.Hour, time.Minute)
+
+	if report.Simulated != time.Minute {
+		t.Errorf("Expected 1m simulated, got %s", report.Simulated)
+	}
+	if report.Away != 10*time.Hour {
+		t.Errorf("Expected 10h away, got %s", report.Away)
+	}
+}
+
+func TestSimulateOfflineReportsDeath(t *testing.T) {
+	clk := clock.NewManual(offlineStart)
+	bird := NewBird("Tweety", "Canary", WithClock(clk))
+
+	report := SimulateOffline(bird, clk, 2*time.Hour, 0)
+
+	if !report.Died {
+		t.Error("Bird left alone for 2 hours should die")
+	}
+	if report.After.IsAlive {
+		t.Error("Status after should show the bird is dead")
+	}
+}
+
+func TestSimulateOfflineCountsCatLives(t *testing.T) {
+	clk := clock.NewManual(offlineStart)
+	cat := NewCat("Whiskers", "Orange", WithClock(clk))
+
+	report := SimulateOffline(cat, clk, 2*time.Hour, 0)
+
+	if report.LivesUsed == 0 {
+		t.Error("Cat left alone for 2 hours should use lives")
+	}
+	if report.LivesUsed != MaxLives-cat.livesRemaining {
+		t.Errorf("Expected %d lives used, got %d", MaxLives-cat.livesRemaining, report.LivesUsed)
+	}
+}
+
+func TestSimulateOfflineAgesThePet(t *testing.T) {
+	clk := clock.NewManual(offlineStart)
+	dog := NewDog("Max", "Golden Retriever", WithClock(clk))
+
+	report := SimulateOffline(dog, clk, 7*time.Minute, 0)
+
+	if report.Before.AgeStage != Baby {
+		t.Errorf("Expected Baby before, got %s", report.Before.AgeStage)
+	}
+	if report.After.AgeStage != Adult {
+		t.Errorf("Expected Adult after 7 minutes away, got %s", report.After.AgeStage)
+	}
+	if !clk.Now().Equal(offlineStart.Add(7 * time.Minute)) {
+		t.Errorf("Clock should have moved 7 minutes, now %v", clk.Now())
+	}
+}
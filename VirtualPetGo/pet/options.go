@@ -0,0 +1,30 @@
+package pet
+
+import (
+	"VirtualPetGo/clock"
+	"math/rand/v2"
+)
+
+// Option configures a pet when it is created or restored
+type Option func(*BasePet)
+
+// WithClock makes the pet read time from c instead of the system clock
+func WithClock(c clock.Clock) Option {
+	return func(bp *BasePet) {
+		bp.clock = c
+	}
+}
+
+// WithRand makes the pet draw illness rolls and other chances from r,
+// so a seeded source gives the same outcomes every run
+func WithRand(r *rand.Rand) Option {
+	return func(bp *BasePet) {
+		bp.rng = r
+	}
+}
+
+func applyOptions(bp *BasePet, opts []Option) {
+	for _, opt := range opts {
+		opt(bp)
+	}
+}
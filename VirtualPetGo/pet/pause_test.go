@@ -0,0 +1,81 @@
+package pet
+
+import (
+	"VirtualPetGo/clock"
+	"testing"
+	"time"
+)
+
+func TestPauseFreezesThePet(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	dog := NewDog("Max", "Golden Retriever", WithClock(clk))
+	dog.UseSpecialAbility()
+	clk.Advance(20 * time.Second)
+	dog.Update(20)
+
+	dog.Pause()
+	before := dog.GetStatus()
+	clk.Advance(10 * time.Minute)
+	dog.Update(600)
+
+	after := dog.GetStatus()
+	if !after.Paused || after.PausedTime != 10*time.Minute {
+		t.Errorf("Expected 10 minutes paused, got %+v", after)
+	}
+	after.PausedTime = before.PausedTime
+	if after != before {
+		t.Errorf("A paused pet should not change:\nbefore %+v\nafter  %+v", before, after)
+	}
+
+	// Loyalty has its 40 seconds left after resuming
+	dog.Resume()
+	if dog.IsPaused() || dog.GetAge() != 20.0/60 {
+		t.Errorf("Expected a running pet aged 20 seconds, got %f minutes", dog.GetAge())
+	}
+	clk.Advance(39 * time.Second)
+	dog.Update(39)
+	if !dog.loyaltyActive {
+		t.Fatal("Loyalty should still be active")
+	}
+	clk.Advance(2 * time.Second)
+	dog.Update(2)
+	if dog.loyaltyActive {
+		t.Error("Loyalty should have run out")
+	}
+
+	status := dog.GetStatus()
+	if status.ActiveTime != 61*time.Second || status.PausedTime != 10*time.Minute {
+		t.Errorf("Expected 61s played and 10m paused, got %s and %s", status.ActiveTime, status.PausedTime)
+	}
+}
+
+func TestPausedPetWaitsOutTimeAway(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	cat := NewCat("Whiskers", "Orange", WithClock(clk))
+	cat.Pause()
+
+	// Saved paused and loaded the next day
+	restored, err := Restore(cat.Snapshot(), WithClock(clk))
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	report := SimulateOffline(restored, clk, 24*time.Hour, 0)
+	if report.After.Hunger != 100 || report.After.Age != 0 {
+		t.Errorf("A paused pet should wait out the time away, got %+v", report.After)
+	}
+	if report.After.PausedTime != 24*time.Hour {
+		t.Errorf("Expected a day paused, got %s", report.After.PausedTime)
+	}
+}
+
+func TestTimeAwayIsNotPlayTime(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	bird := NewBird("Tweety", "Canary", WithClock(clk))
+	clk.Advance(30 * time.Second)
+	bird.Update(30)
+
+	SimulateOffline(bird, clk, time.Hour, 0)
+	if played := bird.GetStatus().ActiveTime; played != 30*time.Second {
+		t.Errorf("Expected 30s played, got %s", played)
+	}
+}
@@ -1,5 +1,7 @@
 package pet
 
+import "time"
+
 // ===== Age Stage Type and Constants =====
 
 type AgeStage string
@@ -25,6 +27,18 @@ type Pet interface {
 	CanUseAbility() bool // Check if ability is available
 	IsIll() bool
 	GetIllness() string
+	Snapshot() Snapshot   // Copy of the full state for saving
+	TakeEvents() []string // Things that happened during Update, cleared once taken
+	SetTimeScale(scale float64)
+	TimeScale() float64
+	Pause() string
+	Resume() string
+	IsPaused() bool
+	Supports(action Action) bool      // Whether the species can do the action at all
+	ActionLabel(action Action) string // Name of the action in the menu
+	Pantry() map[string]int           // Food left, by name
+	Supplies() map[string]int         // Toys, soap and medicine, by name
+	Coins() int
 }
 
 type SpecialAbility interface {
@@ -33,6 +47,8 @@ type SpecialAbility interface {
 type Status struct {
 	Name     string
 	Type     string   // "Dog", "Cat", "Bird"
+	Breed    string   // Empty for species without breeds
+	Traits   string   // Personality traits, comma separated
 	Age      float64  // Age in "Years"
 	AgeStage AgeStage // Baby, Adult, or Elderly
 
@@ -41,6 +57,13 @@ type Status struct {
 	Hunger      int
 	Happiness   int
 	Cleanliness int
+	Tank        bool // Cleanliness is the water quality of a tank (Fish)
+	Energy      int
+	Sleep       SleepState
+	Weight      int
+	WeightTrend WeightTrend
+	Overweight  bool
+	Coins       int
 
 	// Special ability info
 	SpecialAbility string // e.g., "Loyalty - Maintains happiness longer!"
@@ -51,16 +74,46 @@ type Status struct {
 	IsAlive       bool
 
 	// Illness status (Add these)
-	IsIll       bool
-	IllnessName string
+	IsIll        bool
+	IllnessName  string
+	IllnessStage Severity // Mild, Moderate or Severe, empty when well
+
+	// Set once the pet was loaded from a save edited outside the game, it never clears
+	Modified bool
+
+	TimeScale float64 // Pet seconds per real second, 1 is real time
+
+	// Pause state, see BasePet.Pause
+	Paused     bool
+	PausedTime time.Duration // Real time spent paused, including now
+	ActiveTime time.Duration // Real time played while not paused, time away doesn't count
 }
 
-var IllnessTypes = []string{
-	"Cold",
-	"Fleas",
-	"Stomach Bug",
-	"Fever",
-	"Infection",
+// Warnings lists the stats that need attention right now
+func (s Status) Warnings() []string {
+	if !s.IsAlive {
+		return []string{"WARNING: Your pet's health is critical! ⚠️"}
+	}
+
+	var warnings []string
+	if s.Hunger < CriticalStatThreshold {
+		warnings = append(warnings, s.Name+" is very hungry!")
+	}
+	if s.Happiness < CriticalStatThreshold {
+		warnings = append(warnings, s.Name+" is feeling sad!")
+	}
+	if s.Health < CriticalStatThreshold {
+		warnings = append(warnings, s.Name+"'s health is low!")
+	}
+	if s.Cleanliness < CriticalStatThreshold && s.Tank {
+		warnings = append(warnings, s.Name+"'s water is getting murky!")
+	} else if s.Cleanliness < CriticalStatThreshold {
+		warnings = append(warnings, s.Name+" is getting dirty!")
+	}
+	if s.Energy < TiredEnergy && s.Sleep == Awake {
+		warnings = append(warnings, s.Name+" is exhausted and needs sleep!")
+	}
+	return warnings
 }
 
 const (
@@ -88,6 +141,65 @@ const (
 	CleanlinessDecayRate = 1.5
 	HappinessDecayRate   = 1.0 // Only when hunger or cleanliness < 30
 	HealthDecayRate      = 0.5 // When multiple stats are critically low
+	EnergyDecayRate      = 0.25
+	EnergyRecoveryRate   = 2.0 // While asleep, not changed by age
+)
+
+// Energy and sleep
+const (
+	SleepDuration          = 45.0 // seconds for an adult
+	BabySleepMultiplier    = 1.5
+	ElderlySleepMultiplier = 1.3
+	GroggyDuration         = 20.0 // seconds after being woken up
+	WakePenalty            = 15   // Happiness lost when woken up
+	TiredEnergy            = 15   // Below this the pet won't play
+
+	PlayEnergyCost     = 15
+	CleanEnergyCost    = 5
+	InteractEnergyCost = 2
+	AbilityEnergyCost  = 10
+	MiniGameEnergyCost = 5
+)
+
+// Satiety and weight
+const (
+	SatietyDuration     = 30.0 // seconds a pet stays full after a meal
+	FullHunger          = 90   // At or above this the pet is full anyway
+	OverfeedWeight      = 3    // Weight gained from eating while full
+	StomachBugChance    = 0.25 // Chance that eating while full makes the pet ill
+	IdealWeight         = 50
+	HeavyWeight         = 65   // At or above this the pet is heavy
+	OverweightAfter     = 60.0 // seconds heavy before the pet is overweight
+	LeanHunger          = 40   // Below this a pet above its ideal weight slims down
+	WeightLossRate      = 0.05 // Weight points per second
+	PlayWeightLoss      = 1
+	WeightTrendDuration = 60.0 // seconds a weight change shows as a trend
+
+	OverweightPlayPenalty = 10 // Happiness
+	OverweightRestPenalty = 10 // Health
+)
+
+// Food, see food.go
+const (
+	MealNutrition      = 20 // Hunger a standard meal restores
+	MealHappiness      = 5
+	LovedFoodHappiness = 10 // Extra happiness from a food the species loves
+)
+
+// Coins, see coins.go and shop.go
+const (
+	StarterCoins     = 20
+	WellStat         = 60   // Every stat at or above this counts as good care
+	CareCoinInterval = 15.0 // seconds of good care per payment
+	CareCoins        = 1
+	AdultCoins       = 25
+	ElderlyCoins     = 50
+
+	// The Find the Treat mini-game
+	MiniGameCups          = 3
+	MiniGameCoins         = 6
+	MiniGameWinHappiness  = 10
+	MiniGameLoseHappiness = 3
 )
 
 // Critical stat thresholds
@@ -111,9 +223,33 @@ const (
 	SongHappinessBoost   = 25
 	SongHealthBoost      = 15
 	SongCleanlinessBoost = 20
+
+	// FilterCooldown Fish - Filter Boost
+	FilterCooldown   = 120.0 // seconds
+	FilterWaterBoost = 30
+)
+
+// Fish tank parameters
+const (
+	FishWaterDecay  = 0.8 // Water fouls at this times CleanlinessDecayRate
+	FishFullHunger  = 80  // Food given above this hunger is left in the tank
+	FeedFouling     = 3   // Water quality lost on every feed
+	OverfeedFouling = 15  // Extra water quality lost when the fish was full
+)
+
+// Time scale bounds, the scale speeds up or slows down everything time based:
+// decay, aging, illness checks and ability timers
+const (
+	MinTimeScale = 0.1
+	MaxTimeScale = 10.0
 )
+
+// SimulationTick is the fixed step Update advances the pet by (seconds)
+const SimulationTick = 0.1
+
 const (
 	IllnessCheckInterval = 5.0  // Check for illness every 5 seconds
 	BaseIllnessChance    = 0.02 // 2% base chance
 	MaxIllnessChance     = 0.20 // 20% max chance when very dirty
+	CureCleanliness      = 60   // Above this cleaning cures illnesses that cleaning cures
 )
@@ -0,0 +1,92 @@
+package pet
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Descriptor is what the game needs to know about a species to offer it
+type Descriptor struct {
+	Name    string // Display name, also the Type in status and snapshots
+	Blurb   string // One line for the pet selection menu
+	Ability string // What the special ability does
+	Breeds  []Breed
+
+	// New creates a pet of the species, breed is one of Breeds or empty
+	// for species without breeds
+	New func(name, breed string, opts ...Option) Pet
+	// Restore rebuilds a pet from a snapshot of Type Name. Only needed for
+	// species that keep their own state, Dog, Cat and Bird are restored by Restore.
+	Restore func(s Snapshot, opts ...Option) (Pet, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	// registry holds the species in the order they are offered
+	registry = []Descriptor{
+		{
+			Name:    "Dog",
+			Blurb:   "Loyal companion with happiness boost",
+			Ability: "Loyalty - Maintains happiness longer!",
+			Breeds:  breeds["Dog"],
+			New:     func(name, breed string, opts ...Option) Pet { return NewDog(name, breed, opts...) },
+		},
+		{
+			Name:    "Cat",
+			Blurb:   "Independent pet with 9 lives",
+			Ability: "Nine Lives - Can regenerate health!",
+			Breeds:  breeds["Cat"],
+			New:     func(name, breed string, opts ...Option) Pet { return NewCat(name, breed, opts...) },
+		},
+		{
+			Name:    "Bird",
+			Blurb:   "Cheerful singer with stat boosts",
+			Ability: "Song - Boosts all stats!",
+			Breeds:  breeds["Bird"],
+			New:     func(name, breed string, opts ...Option) Pet { return NewBird(name, breed, opts...) },
+		},
+		{
+			Name:    "Fish",
+			Blurb:   "Calm swimmer, keep its tank water clean",
+			Ability: "Filter Boost - Clears up the water!",
+			New:     func(name, _ string, opts ...Option) Pet { return NewFish(name, opts...) },
+		},
+	}
+)
+
+// Register adds a species to the ones the game offers, after those already there.
+// Species from other packages register from an init function. Register panics
+// if the name is taken or the descriptor has no constructor.
+func Register(d Descriptor) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if d.Name == "" || d.New == nil {
+		panic("pet: Register needs a name and a constructor")
+	}
+	for _, existing := range registry {
+		if existing.Name == d.Name {
+			panic(fmt.Sprintf("pet: species %s is registered twice", d.Name))
+		}
+	}
+	registry = append(registry, d)
+}
+
+// Lookup returns the descriptor of a registered species
+func Lookup(name string) (Descriptor, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, d := range registry {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return Descriptor{}, false
+}
+
+// Registered returns every registered species in the order they were registered
+func Registered() []Descriptor {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return append([]Descriptor(nil), registry...)
+}
@@ -0,0 +1,111 @@
+package pet_test
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/pet"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// robot is a species from another package with state of its own
+type robot struct {
+	pet.BasePet
+	recharges int
+}
+
+func (r *robot) MakeSound() string { return "Beep boop" }
+
+func (r *robot) Interact() string { return r.GetName() + " blinks its lights" }
+
+func (r *robot) CanUseAbility() bool { return true }
+
+func (r *robot) UseSpecialAbility() string {
+	r.recharges++
+	return r.GetName() + " recharges"
+}
+
+func (r *robot) GetStatus() pet.Status {
+	s := r.BaseStatus("Robot")
+	s.SpecialAbility = "Recharge - Never runs flat"
+	s.AbilityStatus = fmt.Sprintf("(%d recharges)", r.recharges)
+	return s
+}
+
+// robotState is what a robot keeps in Snapshot.Extra
+type robotState struct {
+	Recharges int `json:"recharges"`
+}
+
+func (r *robot) Snapshot() pet.Snapshot {
+	s := r.BaseSnapshot("Robot")
+	s.Extra, _ = json.Marshal(robotState{Recharges: r.recharges})
+	return s
+}
+
+func init() {
+	pet.Register(pet.Descriptor{
+		Name:    "Robot",
+		Blurb:   "Never needs a walk",
+		Ability: "Recharge - Never runs flat",
+		New: func(name, _ string, opts ...pet.Option) pet.Pet {
+			return &robot{BasePet: pet.NewBasePet(name, opts...)}
+		},
+		Restore: func(s pet.Snapshot, opts ...pet.Option) (pet.Pet, error) {
+			var state robotState
+			if err := json.Unmarshal(s.Extra, &state); err != nil {
+				return nil, fmt.Errorf("robot snapshot %q: %w", s.Name, err)
+			}
+			return &robot{BasePet: pet.RestoreBasePet(s, opts...), recharges: state.Recharges}, nil
+		},
+	})
+}
+
+func TestRegistryOffersEverySpecies(t *testing.T) {
+	var names []string
+	for _, d := range pet.Registered() {
+		names = append(names, d.Name)
+	}
+	want := []string{"Dog", "Cat", "Bird", "Fish", "Hamster"}
+	for i, name := range want {
+		if i >= len(names) || names[i] != name {
+			t.Fatalf("Expected the built in species first, got %v", names)
+		}
+	}
+	if names[len(names)-1] != "Robot" {
+		t.Errorf("Expected the registered species last, got %v", names)
+	}
+}
+
+func TestRegisteredSpeciesRestore(t *testing.T) {
+	d, ok := pet.Lookup("Robot")
+	if !ok {
+		t.Fatal("Robot should be registered")
+	}
+	clk := clock.NewManual(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	p := d.New("Bolt", "", pet.WithClock(clk))
+	p.UseSpecialAbility()
+
+	restored, err := pet.Restore(p.Snapshot(), pet.WithClock(clk))
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if restored.MakeSound() != "Beep boop" || restored.GetStatus() != p.GetStatus() {
+		t.Errorf("Expected the robot back, got %+v", restored.GetStatus())
+	}
+	if status := restored.GetStatus().AbilityStatus; status != "(1 recharges)" {
+		t.Errorf("Expected the robot's own state back, got %s", status)
+	}
+}
+
+func TestRegisterRefusesDuplicates(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Registering Dog again should panic")
+		}
+	}()
+	pet.Register(pet.Descriptor{Name: "Dog", New: func(name, _ string, opts ...pet.Option) pet.Pet {
+		return pet.NewDog(name, "Golden Retriever", opts...)
+	}})
+}
@@ -0,0 +1,222 @@
+package pet
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// ItemKind is what a shop item is for
+type ItemKind string
+
+const (
+	FoodItem     ItemKind = "food"     // Goes into the pantry
+	ToyItem      ItemKind = "toy"      // Kept, the best one adds its effect to every play
+	SoapItem     ItemKind = "soap"     // Used up by a clean, adds its effect
+	MedicineItem ItemKind = "medicine" // Given to cure an illness, adds its effect
+)
+
+// ShopItem is something the shop sells
+type ShopItem struct {
+	Name        string   `json:"name"` // Foods are named like in Foods
+	Kind        ItemKind `json:"kind"`
+	Description string   `json:"description"`
+	Price       int      `json:"price"`    // Coins
+	Quantity    int      `json:"quantity"` // How many one purchase gets, 0 is 1
+	Effect      Effect   `json:"effect"`   // See ItemKind, the message is not used
+}
+
+// quantity is how many one purchase gets
+func (item ShopItem) quantity() int {
+	return max(item.Quantity, 1)
+}
+
+// builtinShop is the catalogue that ships with the game
+//
+//go:embed shop.json
+var builtinShop []byte
+
+var (
+	shopMu sync.RWMutex
+	shop   []ShopItem
+)
+
+func init() {
+	items, err := parseShop(builtinShop)
+	if err != nil {
+		panic(fmt.Sprintf("built-in shop: %v", err))
+	}
+	shop = items
+}
+
+// Shop returns the catalogue in the order the shop lists it
+func Shop() []ShopItem {
+	shopMu.RLock()
+	defer shopMu.RUnlock()
+	return append([]ShopItem(nil), shop...)
+}
+
+// LoadShop replaces the catalogue with the one in path, so prices can be
+// tuned without a new build. A missing file keeps the built in catalogue.
+func LoadShop(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	items, err := parseShop(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	shopMu.Lock()
+	shop = items
+	shopMu.Unlock()
+	return nil
+}
+
+// parseShop decodes and checks a catalogue
+func parseShop(data []byte) ([]ShopItem, error) {
+	var items []ShopItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	for _, item := range items {
+		if item.Name == "" {
+			return nil, errors.New("shop item has no name")
+		}
+		if seen[item.Name] {
+			return nil, fmt.Errorf("shop item %s is listed twice", item.Name)
+		}
+		seen[item.Name] = true
+		if item.Price <= 0 || item.Quantity < 0 {
+			return nil, fmt.Errorf("shop item %s: price must be positive and quantity can't be negative", item.Name)
+		}
+		switch item.Kind {
+		case FoodItem:
+			if _, ok := foodNamed(item.Name); !ok {
+				return nil, fmt.Errorf("shop item %s: unknown food", item.Name)
+			}
+		case ToyItem, SoapItem, MedicineItem:
+		default:
+			return nil, fmt.Errorf("shop item %s: unknown kind %q", item.Name, item.Kind)
+		}
+	}
+	return items, nil
+}
+
+func shopItem(name string) (ShopItem, bool) {
+	for _, item := range Shop() {
+		if item.Name == name {
+			return item, true
+		}
+	}
+	return ShopItem{}, false
+}
+
+// Supplies returns the toys, soap and medicine the pet has, by name
+func (bp *BasePet) Supplies() map[string]int {
+	return copyStock(bp.supplies)
+}
+
+// buy pays for a shop item and puts it in the pantry or the supplies
+func (bp *BasePet) buy(p Pet, name string) string {
+	item, ok := shopItem(name)
+	if !ok {
+		return fmt.Sprintf("The shop doesn't sell %s.", name)
+	}
+	if bp.coins < item.Price {
+		return fmt.Sprintf("%s costs %d coins, you only have %d.", name, item.Price, bp.coins)
+	}
+	switch status := p.GetStatus(); {
+	case item.Kind == ToyItem && !p.Supports(ActionPlay):
+		return fmt.Sprintf("A %s can't play with a %s.", status.Type, name)
+	case item.Kind == ToyItem && bp.supplies[name] > 0:
+		return fmt.Sprintf("%s already has a %s.", bp.GetName(), name)
+	case item.Kind == SoapItem && status.Tank:
+		return fmt.Sprintf("Soap would foul %s's water!", bp.GetName())
+	}
+
+	bp.coins -= item.Price
+	if item.Kind == FoodItem {
+		bp.pantry[name] += item.quantity()
+	} else {
+		bp.supplies[name] += item.quantity()
+	}
+	return fmt.Sprintf("Bought %d %s for %d coins, %d coins left.", item.quantity(), name, item.Price, bp.coins)
+}
+
+// playWithToy adds the effect of the best toy the pet has to a play
+func (bp *BasePet) playWithToy() string {
+	var best ShopItem
+	for _, item := range Shop() {
+		if item.Kind == ToyItem && bp.supplies[item.Name] > 0 &&
+			(best.Name == "" || item.Effect.Happiness > best.Effect.Happiness) {
+			best = item
+		}
+	}
+	if best.Name == "" {
+		return ""
+	}
+	bp.applyEffect(best.Effect)
+	return fmt.Sprintf(" Extra fun with the %s!", best.Name)
+}
+
+// useSoap uses up a bar of soap on a clean, if there is one
+func (bp *BasePet) useSoap() string {
+	for _, item := range Shop() {
+		if item.Kind == SoapItem && bp.supplies[item.Name] > 0 {
+			bp.supplies[item.Name]--
+			bp.applyEffect(item.Effect)
+			return fmt.Sprintf(" Lathered up with %s (%d left)!", item.Name, bp.supplies[item.Name]) + bp.cureIfClean()
+		}
+	}
+	return ""
+}
+
+// medicineRefusal says why the medicine can't be given, empty if it can
+func (bp *BasePet) medicineRefusal(name string) string {
+	item, ok := shopItem(name)
+	switch {
+	case !ok || item.Kind != MedicineItem:
+		return fmt.Sprintf("%s is not a medicine.", name)
+	case bp.supplies[name] <= 0:
+		return fmt.Sprintf("There's no %s left, buy some in the shop.", name)
+	case !bp.isIll:
+		return fmt.Sprintf("%s isn't sick, save the %s for later.", bp.GetName(), name)
+	case !bp.cures(name):
+		return fmt.Sprintf("%s won't help with %s's %s, it needs %s.", name, bp.GetName(), bp.illnessTitle(), bp.illness().Medicine)
+	}
+	return ""
+}
+
+// cures says whether the medicine cures what the pet is ill with, any
+// medicine cures an illness without a name
+func (bp *BasePet) cures(medicine string) bool {
+	wanted := bp.illness().Medicine
+	return wanted == "" || wanted == medicine
+}
+
+// giveMedicine uses up the medicine and cures the illness
+func (bp *BasePet) giveMedicine(name string) string {
+	item, _ := shopItem(name)
+	bp.supplies[name]--
+	bp.applyEffect(item.Effect)
+	illness := bp.illnessTitle()
+	bp.recoverFromIllness()
+	return fmt.Sprintf("%s took the %s and is feeling better! The %s has been cured!", bp.GetName(), name, illness)
+}
+
+// applyEffect changes the core stats by an effect
+func (bp *BasePet) applyEffect(effect Effect) {
+	bp.setHealth(bp.GetHealth() + effect.Health)
+	bp.setHunger(bp.GetHunger() + effect.Hunger)
+	bp.setHappiness(bp.GetHappiness() + effect.Happiness)
+	bp.setCleanliness(bp.GetCleanliness() + effect.Cleanliness)
+}
@@ -0,0 +1,101 @@
+package pet
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuyingFromTheShop(t *testing.T) {
+	dog := NewDog("Max", "Golden Retriever")
+
+	PerformWith(dog, ActionBuy, "Treats")
+	if dog.Coins() != StarterCoins-5 || dog.Pantry()["Treats"] != 8 {
+		t.Errorf("Expected 3 treats for 5 coins, got %d coins and %v", dog.Coins(), dog.Pantry())
+	}
+	if message := PerformWith(dog, ActionBuy, "Feather Wand"); !strings.Contains(message, "only have 15") {
+		t.Errorf("Expected the wand to be too expensive, got %q", message)
+	}
+
+	dog.coins = 100
+	PerformWith(dog, ActionBuy, "Ball")
+	if message := PerformWith(dog, ActionBuy, "Ball"); !strings.Contains(message, "already has a Ball") {
+		t.Errorf("Expected one ball only, got %q", message)
+	}
+	if dog.Coins() != 80 || dog.Supplies()["Ball"] != 1 {
+		t.Errorf("Expected one ball for 20 coins, got %d coins and %v", dog.Coins(), dog.Supplies())
+	}
+}
+
+func TestFishCantUseToysOrSoap(t *testing.T) {
+	fish := NewFish("Bubbles")
+	fish.coins = 100
+	for _, item := range []string{"Ball", "Soap"} {
+		PerformWith(fish, ActionBuy, item)
+	}
+	if fish.Coins() != 100 || len(fish.Supplies()) != 0 {
+		t.Errorf("A fish should not get toys or soap, got %d coins and %v", fish.Coins(), fish.Supplies())
+	}
+}
+
+func TestSuppliesAreUsed(t *testing.T) {
+	cat := NewCat("Whiskers", "Orange")
+	cat.supplies = map[string]int{"Ball": 1, "Soap": 1, "Cold Syrup": 1, "Antibiotics": 1}
+
+	cat.setHappiness(50)
+	if message := Perform(cat, ActionPlay); !strings.Contains(message, "Extra fun with the Ball") {
+		t.Errorf("Expected the ball to be played with, got %q", message)
+	}
+	if cat.Supplies()["Ball"] != 1 {
+		t.Error("Toys should last")
+	}
+
+	cat.setCleanliness(20)
+	Perform(cat, ActionClean)
+	if cat.GetCleanliness() != 20+40+15 || cat.Supplies()["Soap"] != 0 {
+		t.Errorf("Expected the soap to be used up, got cleanliness %d and %v", cat.GetCleanliness(), cat.Supplies())
+	}
+
+	if message := PerformWith(cat, ActionMedicine, "Cold Syrup"); !strings.Contains(message, "isn't sick") {
+		t.Errorf("Medicine should be kept for a sick pet, got %q", message)
+	}
+	cat.fallIll("Cold")
+	if message := PerformWith(cat, ActionMedicine, "Antibiotics"); !strings.Contains(message, "needs Cold Syrup") {
+		t.Errorf("Antibiotics should not cure a Cold, got %q", message)
+	}
+	PerformWith(cat, ActionMedicine, "Cold Syrup")
+	if cat.IsIll() || cat.Supplies()["Cold Syrup"] != 0 || cat.Supplies()["Antibiotics"] != 1 {
+		t.Errorf("Expected the medicine to cure the cat, ill %v, %v", cat.IsIll(), cat.Supplies())
+	}
+}
+
+func TestLoadShop(t *testing.T) {
+	t.Cleanup(func() { shop, _ = parseShop(builtinShop) })
+	dir := t.TempDir()
+
+	if err := LoadShop(filepath.Join(dir, "missing.json")); err != nil || len(Shop()) == 0 {
+		t.Errorf("A missing catalogue should keep the built in one, got %v", err)
+	}
+
+	path := filepath.Join(dir, "shop.json")
+	os.WriteFile(path, []byte(`[{ "name": "Tuna", "kind": "food", "price": 1 }]`), 0644)
+	if err := LoadShop(path); err != nil {
+		t.Fatalf("LoadShop failed: %v", err)
+	}
+	if items := Shop(); len(items) != 1 || items[0].Price != 1 {
+		t.Errorf("Expected the cheap tuna only, got %+v", items)
+	}
+
+	for name, catalogue := range map[string]string{
+		"unknown food": `[{ "name": "Cake", "kind": "food", "price": 1 }]`,
+		"unknown kind": `[{ "name": "Hat", "kind": "clothes", "price": 1 }]`,
+		"free":         `[{ "name": "Ball", "kind": "toy" }]`,
+		"twice":        `[{ "name": "Ball", "kind": "toy", "price": 1 }, { "name": "Ball", "kind": "toy", "price": 2 }]`,
+	} {
+		os.WriteFile(path, []byte(catalogue), 0644)
+		if err := LoadShop(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
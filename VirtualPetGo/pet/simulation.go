@@ -0,0 +1,125 @@
+package pet
+
+import (
+	"math"
+	"time"
+)
+
+// speciesHooks lets a species take part in every simulation step.
+// Go has no virtual methods, so BasePet calls back into the species through this.
+type speciesHooks interface {
+	getHappinessDecayModifier() float64
+	onTick(dt float64, now time.Time)
+	overfeed() string // What eating on a full stomach does, see weight.go
+}
+
+// simulation is the fixed timestep state of a pet: pet time that hasn't been
+// simulated yet and decay that hasn't added up to a whole stat point yet
+type simulation struct {
+	pending      time.Duration
+	illnessTicks int // Ticks since the last illness check
+
+	hunger      float64
+	cleanliness float64
+	happiness   float64
+	health      float64
+	energy      float64
+	weight      float64
+}
+
+var (
+	tickDuration         = secondsToDuration(SimulationTick)
+	ticksPerIllnessCheck = int(math.Round(IllnessCheckInterval / SimulationTick))
+)
+
+// Update advances the pet by deltaTime real seconds, times the time scale,
+// in fixed SimulationTick steps. A paused pet doesn't change. Time shorter than a tick is carried over to
+// the next call, so many small updates end up in the same state as one large
+// update over the same time.
+func (bp *BasePet) Update(deltaTime float64) {
+	end := bp.clock.Now()
+	if bp.IsPaused() {
+		bp.lastUpdateTime = end
+		return
+	}
+	bp.activeTime += secondsToDuration(deltaTime)
+
+	bp.sim.pending += secondsToDuration(deltaTime * bp.timeScale)
+	for bp.sim.pending >= tickDuration {
+		bp.sim.pending -= tickDuration
+		bp.step(SimulationTick, end.Add(-bp.realDuration(bp.sim.pending)))
+	}
+
+	bp.lastUpdateTime = end
+}
+
+// step simulates one tick of dt seconds ending at now
+func (bp *BasePet) step(dt float64, now time.Time) {
+	multiplier := decayMultiplierFor(bp.ageStageAt(now))
+	decay := bp.decay.times(bp.traitDecay())
+
+	// Check for illness every IllnessCheckInterval
+	bp.sim.illnessTicks++
+	if bp.sim.illnessTicks >= ticksPerIllnessCheck {
+		bp.sim.illnessTicks = 0
+		bp.checkForIllness()
+	}
+
+	// Apply hunger decay
+	hungerDecay := HungerDecayRate * dt * multiplier * decay.Hunger
+	bp.setHunger(bp.GetHunger() - drain(&bp.sim.hunger, hungerDecay))
+
+	// Apply cleanliness decay
+	cleanlinessDecay := CleanlinessDecayRate * dt * multiplier * decay.Cleanliness
+	bp.setCleanliness(bp.GetCleanliness() - drain(&bp.sim.cleanliness, cleanlinessDecay))
+
+	// Apply happiness decay with modifier hook
+	if bp.GetHunger() < CriticalStatThreshold || bp.GetCleanliness() < CriticalStatThreshold {
+		happinessDecay := HappinessDecayRate * dt * multiplier * decay.Happiness
+		happinessDecay *= bp.happinessDecayModifier() // Hook for subclasses
+		bp.setHappiness(bp.GetHappiness() - drain(&bp.sim.happiness, happinessDecay))
+	}
+
+	// If ill, the illness takes its toll instead
+	if bp.isIll {
+		bp.suffer(dt, multiplier, decay.Health)
+	} else if bp.getCriticalStatCount() >= 2 {
+		healthDecay := HealthDecayRate * dt * multiplier * decay.Health
+		bp.setHealth(bp.GetHealth() - drain(&bp.sim.health, healthDecay))
+	}
+
+	bp.rest(dt, multiplier)
+	bp.digest(dt)
+	bp.earn(dt, now)
+
+	if bp.species != nil {
+		bp.species.onTick(dt, now)
+	}
+}
+
+// overfed is what overfeeding does to the species
+func (bp *BasePet) overfed() string {
+	if bp.species != nil {
+		return bp.species.overfeed()
+	}
+	return bp.overfeed()
+}
+
+func (bp *BasePet) happinessDecayModifier() float64 {
+	if bp.species != nil {
+		return bp.species.getHappinessDecayModifier()
+	}
+	return bp.getHappinessDecayModifier()
+}
+
+// drain adds amount to a remainder and takes out the whole points, keeping the fraction
+func drain(remainder *float64, amount float64) int {
+	*remainder += amount
+	whole := int(*remainder)
+	*remainder -= float64(whole)
+	return whole
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Round(seconds * float64(time.Second)))
+}
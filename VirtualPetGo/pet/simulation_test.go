@@ -0,0 +1,113 @@
+package pet
+
+import (
+	"VirtualPetGo/clock"
+	"encoding/json"
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+var simulationStart = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestUpdateSmallStepsAccumulateDecay(t *testing.T) {
+	basePet := newBasePet("TestPet")
+
+	// 100 updates of 0.05s used to lose all decay to int truncation
+	for i := 0; i < 100; i++ {
+		basePet.Update(0.05)
+	}
+
+	// 2 points/sec * 5 sec * 1.3 baby multiplier = 13
+	if basePet.GetHunger() != 87 {
+		t.Errorf("Expected hunger 87, got %d", basePet.GetHunger())
+	}
+}
+
+func TestUpdateIsIndependentOfStepSize(t *testing.T) {
+	makePets := func(clk *clock.Manual) []Pet {
+		opts := func() []Option {
+			return []Option{WithClock(clk), WithRand(rand.New(rand.NewPCG(7, 7)))}
+		}
+		dog := NewDog("Max", "Golden Retriever", opts()...)
+		dog.UseSpecialAbility()
+		cat := NewCat("Whiskers", "Orange", opts()...)
+		bird := NewBird("Tweety", "Canary", opts()...)
+		bird.UseSpecialAbility()
+		return []Pet{dog, cat, bird}
+	}
+
+	onceClock := clock.NewManual(simulationStart)
+	once := makePets(onceClock)
+	onceClock.Advance(60 * time.Second)
+	for _, p := range once {
+		p.Update(60)
+	}
+
+	manyClock := clock.NewManual(simulationStart)
+	many := makePets(manyClock)
+	for i := 0; i < 600; i++ {
+		manyClock.Advance(100 * time.Millisecond)
+		for _, p := range many {
+			p.Update(0.1)
+		}
+	}
+
+	for i := range once {
+		want, _ := json.Marshal(once[i].Snapshot())
+		got, _ := json.Marshal(many[i].Snapshot())
+		if string(got) != string(want) {
+			t.Errorf("600 small updates differ from one large update:\nonce %s\nmany %s", want, got)
+		}
+	}
+}
+
+func TestUpdateChecksIllnessOnInterval(t *testing.T) {
+	basePet := newBasePet("TestPet")
+
+	basePet.Update(IllnessCheckInterval - SimulationTick)
+	if basePet.sim.illnessTicks != ticksPerIllnessCheck-1 {
+		t.Errorf("Expected %d ticks since the last check, got %d", ticksPerIllnessCheck-1, basePet.sim.illnessTicks)
+	}
+
+	basePet.Update(SimulationTick)
+	if basePet.sim.illnessTicks != 0 {
+		t.Errorf("Illness should have been checked after %.0f seconds", IllnessCheckInterval)
+	}
+}
+
+func TestUpdateCarriesPartialTicks(t *testing.T) {
+	basePet := newBasePet("TestPet")
+
+	basePet.Update(0.25)
+
+	if basePet.sim.pending != 50*time.Millisecond {
+		t.Errorf("Expected 50ms carried over, got %s", basePet.sim.pending)
+	}
+}
+
+func TestRestoreKeepsSimulationState(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	// No illness check happens before the snapshot, so both random sources are still in step
+	original := NewDog("Max", "Golden Retriever", WithClock(clk), WithRand(rand.New(rand.NewPCG(3, 3))))
+	clk.Advance(2345 * time.Millisecond)
+	original.Update(2.345)
+
+	restored, err := Restore(original.Snapshot(), WithClock(clk), WithRand(rand.New(rand.NewPCG(3, 3))))
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if got, want := restored.Snapshot().Simulation, original.Snapshot().Simulation; got != want {
+		t.Errorf("Simulation state lost on restore:\nwant %+v\ngot  %+v", want, got)
+	}
+
+	// Both carry on identically
+	clk.Advance(3 * time.Second)
+	original.Update(3)
+	restored.Update(3)
+	want, _ := json.Marshal(original.Snapshot())
+	got, _ := json.Marshal(restored.Snapshot())
+	if string(got) != string(want) {
+		t.Errorf("Restored pet drifted:\nwant %s\ngot  %s", want, got)
+	}
+}
@@ -0,0 +1,130 @@
+package pet
+
+import "fmt"
+
+// SleepState is whether the pet is awake, asleep or groggy from being woken
+type SleepState string
+
+const (
+	Awake  SleepState = "Awake"
+	Asleep SleepState = "Asleep"
+	Groggy SleepState = "Groggy"
+)
+
+// energyCosts is the energy an action takes out of the pet
+var energyCosts = map[Action]int{
+	ActionPlay:     PlayEnergyCost,
+	ActionClean:    CleanEnergyCost,
+	ActionInteract: InteractEnergyCost,
+	ActionAbility:  AbilityEnergyCost,
+	ActionMiniGame: MiniGameEnergyCost,
+}
+
+// wakingActions wake a sleeping pet up
+var wakingActions = map[Action]bool{
+	ActionFeed:     true,
+	ActionPlay:     true,
+	ActionClean:    true,
+	ActionInteract: true,
+	ActionAbility:  true,
+	ActionMiniGame: true,
+	ActionMedicine: true,
+}
+
+func (bp *BasePet) GetEnergy() int {
+	return bp.energy
+}
+
+func (bp *BasePet) setEnergy(value int) {
+	bp.energy = clampStat(value)
+}
+
+// SleepState returns whether the pet is awake, asleep or groggy
+func (bp *BasePet) SleepState() SleepState {
+	switch {
+	case bp.asleepFor > 0:
+		return Asleep
+	case bp.groggyFor > 0:
+		return Groggy
+	}
+	return Awake
+}
+
+// sleepDuration is how long a nap lasts in pet seconds, babies and the
+// elderly need more sleep
+func (bp *BasePet) sleepDuration() float64 {
+	switch bp.getAgeStage() {
+	case Baby:
+		return SleepDuration * BabySleepMultiplier
+	case Elderly:
+		return SleepDuration * ElderlySleepMultiplier
+	}
+	return SleepDuration
+}
+
+// fallAsleep starts a nap and says for how long. The health rested by Sleep
+// is held back until the pet wakes up on its own, so waking it and putting
+// it back to bed doesn't restore health again and again.
+func (bp *BasePet) fallAsleep(rested int) string {
+	bp.napHealth = rested
+	bp.setHealth(bp.health - rested)
+	bp.startNap()
+	return fmt.Sprintf(" Zzz... (sleeping for %.0f seconds)", bp.asleepFor)
+}
+
+// startNap puts the pet to sleep. The energy remainder goes, a fraction
+// drained while awake doesn't count towards the energy regained asleep and
+// the other way round.
+func (bp *BasePet) startNap() {
+	bp.asleepFor = bp.sleepDuration()
+	bp.groggyFor = 0
+	bp.sim.energy = 0
+}
+
+// wakeUp ends a nap early, the pet is grumpy and groggy for a while and
+// misses out on the health of the nap
+func (bp *BasePet) wakeUp() string {
+	bp.asleepFor = 0
+	bp.groggyFor = GroggyDuration
+	bp.napHealth = 0
+	bp.sim.energy = 0
+	bp.setHappiness(bp.GetHappiness() - WakePenalty)
+	return bp.GetName() + " was woken up and is grumpy!"
+}
+
+// restRefusal is why the pet won't do the action right now, empty if it will
+func (bp *BasePet) restRefusal(action Action) string {
+	switch {
+	case action == ActionSleep && bp.SleepState() == Asleep:
+		return bp.GetName() + " is already asleep."
+	case action == ActionSleep && bp.SleepState() == Groggy:
+		return bp.GetName() + " is too groggy to fall back asleep."
+	case (action == ActionPlay || action == ActionMiniGame) && bp.SleepState() == Groggy:
+		return bp.GetName() + " is too groggy to play."
+	case (action == ActionPlay || action == ActionMiniGame) && bp.energy < TiredEnergy:
+		return bp.GetName() + " is too tired to play, let them sleep."
+	}
+	return ""
+}
+
+// rest drains energy while awake and restores it while asleep
+func (bp *BasePet) rest(dt, multiplier float64) {
+	if bp.asleepFor > 0 {
+		bp.setEnergy(bp.energy + drain(&bp.sim.energy, EnergyRecoveryRate*dt))
+		if bp.asleepFor = countDown(bp.asleepFor, dt); bp.asleepFor == 0 {
+			bp.setHealth(bp.health + bp.napHealth)
+			bp.napHealth = 0
+			bp.sim.energy = 0
+			bp.notify("💤 " + bp.GetName() + " woke up well rested!")
+			bp.restCure()
+		}
+		return
+	}
+
+	bp.groggyFor = countDown(bp.groggyFor, dt)
+	bp.setEnergy(bp.energy - drain(&bp.sim.energy, EnergyDecayRate*dt*multiplier))
+	if bp.energy == 0 {
+		bp.startNap()
+		bp.notify("💤 " + bp.GetName() + " fell asleep from exhaustion!")
+	}
+}
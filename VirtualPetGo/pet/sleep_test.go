@@ -0,0 +1,171 @@
+package pet
+
+import (
+	"VirtualPetGo/clock"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSleepIsATimedState(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	dog := NewDog("Max", "Golden Retriever", WithClock(clk), WithRand(rand.New(rand.NewPCG(1, 1))))
+	dog.setEnergy(40)
+
+	Perform(dog, ActionSleep)
+	if dog.SleepState() != Asleep {
+		t.Fatalf("Expected the dog to be asleep, got %s", dog.SleepState())
+	}
+	if message := Perform(dog, ActionSleep); message != "Max is already asleep." {
+		t.Errorf("Sleeping again should be refused, got %q", message)
+	}
+
+	// A baby sleeps 1.5 times as long as an adult, energy comes back meanwhile
+	clk.Advance(60 * time.Second)
+	dog.Update(60)
+	if dog.SleepState() != Asleep || dog.GetEnergy() != 100 {
+		t.Errorf("Expected a sleeping dog with full energy, got %s with %d", dog.SleepState(), dog.GetEnergy())
+	}
+
+	clk.Advance(10 * time.Second)
+	dog.Update(10)
+	if dog.SleepState() != Awake {
+		t.Errorf("Expected the dog to wake up after %g seconds, got %s", SleepDuration*BabySleepMultiplier, dog.SleepState())
+	}
+	if events := dog.TakeEvents(); len(events) != 1 || !strings.Contains(events[0], "well rested") {
+		t.Errorf("Expected a wake up event, got %v", events)
+	}
+}
+
+func TestSleepLastsLongerForBabiesAndElderly(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	dog := NewDog("Max", "Golden Retriever", WithClock(clk))
+
+	durations := map[AgeStage]float64{}
+	for _, age := range []time.Duration{0, 6 * time.Minute, 10 * time.Minute} {
+		clk.Advance(age)
+		durations[dog.getAgeStage()] = dog.sleepDuration()
+	}
+	if durations[Baby] != 67.5 || durations[Adult] != 45 || durations[Elderly] != 58.5 {
+		t.Errorf("Unexpected sleep durations %v", durations)
+	}
+}
+
+func TestWakingUpMakesThePetGroggy(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	cat := NewCat("Whiskers", "Orange", WithClock(clk))
+	Perform(cat, ActionSleep)
+	cat.setHappiness(50)
+	cat.setHunger(50)
+
+	message := Perform(cat, ActionFeed)
+	if !strings.HasPrefix(message, "Whiskers was woken up and is grumpy!") {
+		t.Errorf("Expected a wake up message, got %q", message)
+	}
+	// Feeding still happened, +5 happiness after the penalty
+	if cat.SleepState() != Groggy || cat.GetHappiness() != 50-WakePenalty+5 || cat.GetHunger() != 70 {
+		t.Errorf("Expected a fed groggy cat, got %s %+v", cat.SleepState(), cat.GetStatus())
+	}
+
+	if message := Perform(cat, ActionPlay); message != "Whiskers is too groggy to play." {
+		t.Errorf("A groggy pet should not play, got %q", message)
+	}
+
+	clk.Advance(time.Duration(GroggyDuration) * time.Second)
+	cat.Update(GroggyDuration)
+	if cat.SleepState() != Awake {
+		t.Errorf("Grogginess should wear off, got %s", cat.SleepState())
+	}
+}
+
+func TestActivitiesDrainEnergy(t *testing.T) {
+	bird := NewBird("Tweety", "Canary")
+
+	Perform(bird, ActionPlay)
+	Perform(bird, ActionClean)
+	if want := MaxStat - PlayEnergyCost - CleanEnergyCost; bird.GetEnergy() != want {
+		t.Errorf("Expected energy %d, got %d", want, bird.GetEnergy())
+	}
+
+	bird.setEnergy(TiredEnergy - 1)
+	bird.setHappiness(50)
+	if message := Perform(bird, ActionPlay); !strings.Contains(message, "too tired") || bird.GetHappiness() != 50 {
+		t.Errorf("A tired pet should not play, got %q", message)
+	}
+}
+
+func TestExhaustedPetFallsAsleep(t *testing.T) {
+	bird := NewBird("Tweety", "Canary")
+	bird.setEnergy(1)
+
+	bird.Update(5)
+
+	if bird.SleepState() != Asleep {
+		t.Errorf("Expected an exhausted bird to fall asleep, got %s with %d energy", bird.SleepState(), bird.GetEnergy())
+	}
+}
+
+func TestSleepIsKeptOnRestore(t *testing.T) {
+	dog := NewDog("Max", "Golden Retriever")
+	dog.setEnergy(20)
+	Perform(dog, ActionSleep)
+
+	restored, err := Restore(dog.Snapshot())
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	status := restored.GetStatus()
+	if status.Sleep != Asleep || status.Energy != 20 {
+		t.Errorf("Sleep lost on restore, got %s with %d energy", status.Sleep, status.Energy)
+	}
+}
+
+func TestNapHealthOnlyComesFromSleepingItOut(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	dog := NewDog("Max", "Golden Retriever", WithClock(clk), WithRand(rand.New(rand.NewPCG(1, 1))))
+	dog.setHealth(50)
+
+	// Putting the dog to bed and waking it again restores nothing
+	for range 3 {
+		Perform(dog, ActionSleep)
+		Perform(dog, ActionInteract)
+		if message := Perform(dog, ActionSleep); message != "Max is too groggy to fall back asleep." {
+			t.Fatalf("A groggy pet should not go back to sleep, got %q", message)
+		}
+		dog.groggyFor = 0
+	}
+	if dog.GetHealth() != 50 {
+		t.Errorf("Waking the dog up should forfeit the nap's health, got %d", dog.GetHealth())
+	}
+
+	Perform(dog, ActionSleep)
+	if dog.GetHealth() != 50 {
+		t.Errorf("The nap's health should wait for the nap to end, got %d", dog.GetHealth())
+	}
+	dog.asleepFor = 1
+	clk.Advance(time.Second)
+	dog.Update(1)
+	if dog.SleepState() != Awake || dog.GetHealth() != 70 {
+		t.Errorf("A full nap should restore 20 health, got %d", dog.GetHealth())
+	}
+}
+
+func TestSleepStartsTheEnergyRemainderOver(t *testing.T) {
+	dog := NewDog("Max", "Golden Retriever", WithRand(rand.New(rand.NewPCG(1, 1))))
+	dog.setEnergy(50)
+	dog.sim.energy = 0.9 // Almost a point drained while awake
+
+	Perform(dog, ActionSleep)
+	dog.rest(0.1, 1)
+	if dog.GetEnergy() != 50 {
+		t.Errorf("Energy drained awake should not count towards sleep, got %d", dog.GetEnergy())
+	}
+
+	dog.sim.energy = 0.9 // Almost a point regained asleep
+	Perform(dog, ActionInteract)
+	dog.rest(0.5, 1)
+	if dog.GetEnergy() != 50-InteractEnergyCost {
+		t.Errorf("Energy regained asleep should not count towards tiring, got %d", dog.GetEnergy())
+	}
+}
@@ -0,0 +1,416 @@
+package pet
+
+import (
+	"VirtualPetGo/clock"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Snapshot is a plain copy of everything needed to rebuild a pet,
+// including the private BasePet fields and the species state.
+type Snapshot struct {
+	Type      string    `json:"type"` // "Dog", "Cat", "Bird"
+	Breed     string    `json:"breed,omitempty"`
+	Traits    []string  `json:"traits,omitempty"`
+	Name      string    `json:"name"`
+	BirthTime time.Time `json:"birth_time"`
+
+	// Core stats (0-100)
+	Health      int `json:"health"`
+	Hunger      int `json:"hunger"`
+	Happiness   int `json:"happiness"`
+	Cleanliness int `json:"cleanliness"`
+	Energy      int `json:"energy"`
+
+	// Sleep state, see SleepState
+	AsleepSeconds float64 `json:"asleep_seconds,omitempty"` // Sleep left
+	GroggySeconds float64 `json:"groggy_seconds,omitempty"` // Grogginess left
+	NapHealth     int     `json:"nap_health,omitempty"`     // Health restored when the nap ends
+
+	// Weight, see weight.go
+	Weight             int     `json:"weight"`
+	SatietySeconds     float64 `json:"satiety_seconds,omitempty"`      // Left of the last meal
+	HeavySeconds       float64 `json:"heavy_seconds,omitempty"`        // Time at HeavyWeight or above
+	WeightTrendSeconds float64 `json:"weight_trend_seconds,omitempty"` // Negative while losing
+
+	// Food left by name, missing is the starter pantry
+	Pantry map[string]int `json:"pantry,omitempty"`
+
+	// Coins and what they bought, see coins.go and shop.go
+	Coins       int            `json:"coins"`
+	Supplies    map[string]int `json:"supplies,omitempty"`
+	WellSeconds float64        `json:"well_seconds,omitempty"` // Good care towards the next coins
+	Milestone   AgeStage       `json:"milestone,omitempty"`    // Missing is the age stage on restore
+
+	// Illness status
+	IsIll       bool    `json:"is_ill"`
+	IllnessName string  `json:"illness_name,omitempty"`
+	SickSeconds float64 `json:"sick_seconds,omitempty"` // Time ill, sets the stage, see illness.go
+
+	// Loaded from a save that was edited outside the game at some point
+	Modified bool `json:"modified,omitempty"`
+
+	// Pet seconds per real second, BirthTime and the ability timers are anchored
+	// to it. 0 is read as 1, real time.
+	TimeScale float64 `json:"time_scale"`
+
+	// Pause state and play time, see BasePet.Pause
+	PausedAt      time.Time `json:"paused_at,omitzero"` // Zero while running
+	PausedSeconds float64   `json:"paused_seconds"`     // Before PausedAt
+	ActiveSeconds float64   `json:"active_seconds"`
+
+	// Fixed timestep leftovers, see simulation
+	Simulation SimulationState `json:"simulation"`
+
+	// Species state, only the one matching Type is set
+	Dog  *DogState  `json:"dog,omitempty"`
+	Cat  *CatState  `json:"cat,omitempty"`
+	Bird *BirdState `json:"bird,omitempty"`
+
+	Fish *FishState `json:"fish,omitempty"`
+
+	// Set for data driven species, Type is the species name
+	Generic *GenericState `json:"generic,omitempty"`
+
+	// State of a species registered from another package, in whatever form it likes
+	Extra json.RawMessage `json:"extra,omitempty"`
+}
+
+type SimulationState struct {
+	PendingSeconds float64 `json:"pending_seconds"`
+	IllnessTicks   int     `json:"illness_ticks"`
+
+	// Decay not yet applied because it is less than one point
+	HungerRemainder      float64 `json:"hunger_remainder"`
+	CleanlinessRemainder float64 `json:"cleanliness_remainder"`
+	HappinessRemainder   float64 `json:"happiness_remainder"`
+	HealthRemainder      float64 `json:"health_remainder"`
+	EnergyRemainder      float64 `json:"energy_remainder,omitempty"`
+	WeightRemainder      float64 `json:"weight_remainder,omitempty"`
+}
+
+type DogState struct {
+	LoyaltyActive  bool      `json:"loyalty_active"`
+	LoyaltyEndTime time.Time `json:"loyalty_end_time"`
+}
+
+type CatState struct {
+	LivesRemaining int `json:"lives_remaining"`
+}
+
+type FishState struct {
+	FilterCooldown float64 `json:"filter_cooldown"` // seconds left
+}
+
+type GenericState struct {
+	Cooldown      float64 `json:"cooldown"`   // seconds left
+	ActiveFor     float64 `json:"active_for"` // seconds left
+	UsesRemaining int     `json:"uses_remaining"`
+	TimesUsed     int     `json:"times_used"`
+}
+
+type BirdState struct {
+	SongCooldown   float64 `json:"song_cooldown"` // seconds left
+	SongsPerformed int     `json:"songs_performed"`
+}
+
+// Restore rebuilds a pet from a snapshot
+func Restore(s Snapshot, opts ...Option) (Pet, error) {
+	switch s.Type {
+	case "Dog":
+		if s.Dog == nil {
+			return nil, fmt.Errorf("dog snapshot %q has no dog state", s.Name)
+		}
+		d := &Dog{
+			BasePet:        restoreBasePet(s, opts),
+			loyaltyActive:  s.Dog.LoyaltyActive,
+			loyaltyEndTime: s.Dog.LoyaltyEndTime,
+		}
+		d.species = d
+		d.setBreed(s.breedOr("Dog"))
+		return d, nil
+
+	case "Cat":
+		if s.Cat == nil {
+			return nil, fmt.Errorf("cat snapshot %q has no cat state", s.Name)
+		}
+		c := &Cat{
+			BasePet:        restoreBasePet(s, opts),
+			livesRemaining: s.Cat.LivesRemaining,
+		}
+		c.species = c
+		c.setBreed(s.breedOr("Cat"))
+		return c, nil
+
+	case "Bird":
+		if s.Bird == nil {
+			return nil, fmt.Errorf("bird snapshot %q has no bird state", s.Name)
+		}
+		b := &Bird{
+			BasePet:        restoreBasePet(s, opts),
+			songCooldown:   s.Bird.SongCooldown,
+			songsPerformed: s.Bird.SongsPerformed,
+		}
+		b.species = b
+		b.setBreed(s.breedOr("Bird"))
+		return b, nil
+
+	case "Fish":
+		if s.Fish == nil {
+			return nil, fmt.Errorf("fish snapshot %q has no fish state", s.Name)
+		}
+		f := &Fish{
+			BasePet:        restoreBasePet(s, opts),
+			filterCooldown: s.Fish.FilterCooldown,
+		}
+		f.setup()
+		return f, nil
+
+	default:
+		// Species added to the registry restore themselves
+		d, ok := Lookup(s.Type)
+		if !ok || d.Restore == nil {
+			return nil, fmt.Errorf("unknown pet type %q", s.Type)
+		}
+		return d.Restore(s, opts...)
+	}
+}
+
+// restoreGeneric rebuilds a pet of a data driven species
+func restoreGeneric(s Snapshot, opts ...Option) (Pet, error) {
+	def, ok := Species(s.Type)
+	if !ok {
+		return nil, fmt.Errorf("unknown pet type %q", s.Type)
+	}
+	if s.Generic == nil {
+		return nil, fmt.Errorf("%s snapshot %q has no species state", s.Type, s.Name)
+	}
+	g := &Generic{
+		BasePet:       restoreBasePet(s, opts),
+		def:           def,
+		cooldown:      s.Generic.Cooldown,
+		activeFor:     s.Generic.ActiveFor,
+		usesRemaining: s.Generic.UsesRemaining,
+		timesUsed:     s.Generic.TimesUsed,
+	}
+	g.setup()
+	return g, nil
+}
+
+// snapshot copies the shared BasePet fields, species fill in the rest
+func (bp *BasePet) snapshot(petType string) Snapshot {
+	return Snapshot{
+		Type:               petType,
+		Breed:              bp.breed,
+		Traits:             bp.Traits(),
+		Name:               bp.name,
+		BirthTime:          bp.birthTime,
+		Health:             bp.health,
+		Hunger:             bp.hunger,
+		Happiness:          bp.happiness,
+		Cleanliness:        bp.cleanliness,
+		Energy:             bp.energy,
+		AsleepSeconds:      bp.asleepFor,
+		GroggySeconds:      bp.groggyFor,
+		NapHealth:          bp.napHealth,
+		Weight:             bp.weight,
+		SatietySeconds:     bp.satietyFor,
+		HeavySeconds:       bp.heavyFor,
+		WeightTrendSeconds: bp.trendFor,
+		Pantry:             bp.Pantry(),
+		Coins:              bp.coins,
+		Supplies:           bp.Supplies(),
+		WellSeconds:        bp.wellFor,
+		Milestone:          bp.milestone,
+		IsIll:              bp.isIll,
+		IllnessName:        bp.illnessName,
+		SickSeconds:        bp.sickFor,
+		Modified:           bp.modified,
+		TimeScale:          bp.timeScale,
+
+		PausedAt:      bp.pausedAt,
+		PausedSeconds: bp.pausedTime.Seconds(),
+		ActiveSeconds: bp.activeTime.Seconds(),
+
+		Simulation: SimulationState{
+			PendingSeconds:       bp.sim.pending.Seconds(),
+			IllnessTicks:         bp.sim.illnessTicks,
+			HungerRemainder:      bp.sim.hunger,
+			CleanlinessRemainder: bp.sim.cleanliness,
+			HappinessRemainder:   bp.sim.happiness,
+			HealthRemainder:      bp.sim.health,
+			EnergyRemainder:      bp.sim.energy,
+			WeightRemainder:      bp.sim.weight,
+		},
+	}
+}
+
+// BaseSnapshot copies the shared state, a species from another package
+// adds its own as Extra
+func (bp *BasePet) BaseSnapshot(petType string) Snapshot {
+	return bp.snapshot(petType)
+}
+
+// breedOr is the breed of the snapshot, pets from before breeds are the
+// species' default breed
+func (s Snapshot) breedOr(species string) string {
+	if s.Breed == "" {
+		return defaultBreed(species)
+	}
+	return s.Breed
+}
+
+// RestoreBasePet rebuilds the shared state from a snapshot, for the Restore
+// of a species from another package
+func RestoreBasePet(s Snapshot, opts ...Option) BasePet {
+	return restoreBasePet(s, opts)
+}
+
+func restoreBasePet(s Snapshot, opts []Option) BasePet {
+	bp := BasePet{
+		name:        s.Name,
+		birthTime:   s.BirthTime,
+		health:      clampStat(s.Health),
+		hunger:      clampStat(s.Hunger),
+		happiness:   clampStat(s.Happiness),
+		cleanliness: clampStat(s.Cleanliness),
+		energy:      clampStat(s.Energy),
+		asleepFor:   s.AsleepSeconds,
+		groggyFor:   s.GroggySeconds,
+		napHealth:   s.NapHealth,
+		weight:      clampStat(s.Weight),
+		satietyFor:  s.SatietySeconds,
+		heavyFor:    s.HeavySeconds,
+		trendFor:    s.WeightTrendSeconds,
+		pantry:      s.pantry(),
+		supplies:    copyStock(s.Supplies),
+		coins:       s.Coins,
+		wellFor:     s.WellSeconds,
+		milestone:   s.Milestone,
+		isIll:       s.IsIll,
+		illnessName: s.IllnessName,
+		sickFor:     s.SickSeconds,
+		modified:    s.Modified,
+		traits:      lookupTraits(s.Traits),
+		clock:       clock.Real{},
+		decay:       normalDecay,
+		timeScale:   s.timeScale(),
+		pausedAt:    s.PausedAt,
+		pausedTime:  secondsToDuration(s.PausedSeconds),
+		activeTime:  secondsToDuration(s.ActiveSeconds),
+		sim: simulation{
+			pending:      secondsToDuration(s.Simulation.PendingSeconds),
+			illnessTicks: s.Simulation.IllnessTicks,
+			hunger:       s.Simulation.HungerRemainder,
+			cleanliness:  s.Simulation.CleanlinessRemainder,
+			happiness:    s.Simulation.HappinessRemainder,
+			health:       s.Simulation.HealthRemainder,
+			energy:       s.Simulation.EnergyRemainder,
+			weight:       s.Simulation.WeightRemainder,
+		},
+	}
+	applyOptions(&bp, opts)
+	bp.ensureRand()
+
+	bp.lastUpdateTime = bp.clock.Now()
+	if bp.milestone == "" {
+		// Pets from before milestones aren't paid for growing up again
+		bp.milestone = bp.getAgeStage()
+	}
+	return bp
+}
+
+// Rescaled returns the snapshot at another time scale. As of time at the pet
+// keeps its age and the pet time left on its ability timers.
+func (s Snapshot) Rescaled(at time.Time, scale float64) Snapshot {
+	from := s.timeScale()
+	scale = clampTimeScale(scale)
+	if !s.PausedAt.IsZero() {
+		// The pet's clock stopped when it was paused
+		at = s.PausedAt
+	}
+
+	s.BirthTime = rescaleTime(s.BirthTime, at, from, scale)
+	if s.Dog != nil && s.Dog.LoyaltyActive {
+		dog := *s.Dog
+		dog.LoyaltyEndTime = rescaleTime(dog.LoyaltyEndTime, at, from, scale)
+		s.Dog = &dog
+	}
+	s.TimeScale = scale
+	return s
+}
+
+// Resumed returns the snapshot as if the pet was resumed at time at
+func (s Snapshot) Resumed(at time.Time) Snapshot {
+	if s.PausedAt.IsZero() {
+		return s
+	}
+	paused := at.Sub(s.PausedAt)
+	s.BirthTime = s.BirthTime.Add(paused)
+	if s.Dog != nil && s.Dog.LoyaltyActive {
+		dog := *s.Dog
+		dog.LoyaltyEndTime = dog.LoyaltyEndTime.Add(paused)
+		s.Dog = &dog
+	}
+	s.PausedSeconds += paused.Seconds()
+	s.PausedAt = time.Time{}
+	return s
+}
+
+// pantry is the food left, pets from before the pantry get the starter pantry
+func (s Snapshot) pantry() map[string]int {
+	if s.Pantry == nil {
+		return StarterPantry()
+	}
+	return copyStock(s.Pantry)
+}
+
+// timeScale reads a missing time scale as real time
+func (s Snapshot) timeScale() float64 {
+	if s.TimeScale == 0 {
+		return 1
+	}
+	return clampTimeScale(s.TimeScale)
+}
+
+func (d *Dog) Snapshot() Snapshot {
+	s := d.BasePet.snapshot("Dog")
+	s.Dog = &DogState{
+		LoyaltyActive:  d.loyaltyActive,
+		LoyaltyEndTime: d.loyaltyEndTime,
+	}
+	return s
+}
+
+func (c *Cat) Snapshot() Snapshot {
+	s := c.BasePet.snapshot("Cat")
+	s.Cat = &CatState{LivesRemaining: c.livesRemaining}
+	return s
+}
+
+func (b *Bird) Snapshot() Snapshot {
+	s := b.BasePet.snapshot("Bird")
+	s.Bird = &BirdState{
+		SongCooldown:   b.songCooldown,
+		SongsPerformed: b.songsPerformed,
+	}
+	return s
+}
+
+func (f *Fish) Snapshot() Snapshot {
+	s := f.BasePet.snapshot("Fish")
+	s.Fish = &FishState{FilterCooldown: f.filterCooldown}
+	return s
+}
+
+func (g *Generic) Snapshot() Snapshot {
+	s := g.BasePet.snapshot(g.def.Name)
+	s.Generic = &GenericState{
+		Cooldown:      g.cooldown,
+		ActiveFor:     g.activeFor,
+		UsesRemaining: g.usesRemaining,
+		TimesUsed:     g.timesUsed,
+	}
+	return s
+}
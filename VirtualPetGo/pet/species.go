@@ -0,0 +1,210 @@
+package pet
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// SpeciesDef describes a species in data, the Generic pet plays it.
+// See pet/species/*.json for the species that ship with the game.
+type SpeciesDef struct {
+	Name     string `json:"name"`
+	Blurb    string `json:"blurb"`    // One line for the pet selection menu
+	Sound    string `json:"sound"`    // What MakeSound returns
+	Interact string `json:"interact"` // Message for Interact, see Text
+
+	// Stat changes for feed, play, sleep and clean. Missing actions do what BasePet does.
+	Actions map[Action]Effect `json:"actions"`
+	Decay   Decay             `json:"decay"`
+	Ability AbilityDef        `json:"ability"`
+	Tastes  Tastes            `json:"tastes"` // Foods it loves and refuses, see food.go
+}
+
+// Effect is a change to the core stats and the message that goes with it
+type Effect struct {
+	Health      int    `json:"health"`
+	Hunger      int    `json:"hunger"`
+	Happiness   int    `json:"happiness"`
+	Cleanliness int    `json:"cleanliness"`
+	Message     string `json:"message"` // See Text
+}
+
+// Decay multiplies the decay rates, on top of the age multiplier. Missing or zero rates are 1.
+type Decay struct {
+	Hunger      float64 `json:"hunger"`
+	Cleanliness float64 `json:"cleanliness"`
+	Happiness   float64 `json:"happiness"`
+	Health      float64 `json:"health"`
+}
+
+// normalDecay leaves the decay rates as they are
+var normalDecay = Decay{Hunger: 1, Cleanliness: 1, Happiness: 1, Health: 1}
+
+// orNormal fills in the rates that are missing with 1
+func (d Decay) orNormal() Decay {
+	for _, rate := range []*float64{&d.Hunger, &d.Cleanliness, &d.Happiness, &d.Health} {
+		if *rate == 0 {
+			*rate = 1
+		}
+	}
+	return d
+}
+
+// AbilityDef is a special ability built from parameters. Every use applies
+// Effect, the other parameters are optional and can be combined.
+type AbilityDef struct {
+	Name        string `json:"name"`
+	Description string `json:"description"` // Shown after the name on the status screen
+	Message     string `json:"message"`     // Shown on use, see Text
+	Effect      Effect `json:"effect"`
+
+	Cooldown       float64 `json:"cooldown"`        // Seconds before it can be used again
+	Duration       float64 `json:"duration"`        // Seconds it stays active after a use
+	HappinessDecay float64 `json:"happiness_decay"` // Happiness decay multiplier while active, missing or zero is 1
+	Uses           int     `json:"uses"`            // Uses in a lifetime, 0 for no limit
+	Revive         bool    `json:"revive"`          // Spend a use to bring the pet back at full health when it dies
+}
+
+// Text fills in {name} and {sound} in a message of a species definition
+func (def *SpeciesDef) Text(message, name string) string {
+	return strings.NewReplacer("{name}", name, "{sound}", def.Sound).Replace(message)
+}
+
+// validate checks a definition before it is registered
+func (def *SpeciesDef) validate() error {
+	if def.Name == "" {
+		return errors.New("species has no name")
+	}
+	for action := range def.Actions {
+		switch action {
+		case ActionFeed, ActionPlay, ActionSleep, ActionClean:
+		default:
+			return fmt.Errorf("species %s: action %q can't have an effect", def.Name, action)
+		}
+	}
+	for _, name := range slices.Concat(def.Tastes.Loves, def.Tastes.Refuses) {
+		if _, ok := foodNamed(name); !ok {
+			return fmt.Errorf("species %s: unknown food %q", def.Name, name)
+		}
+	}
+	decay := def.Decay
+	if decay.Hunger < 0 || decay.Cleanliness < 0 || decay.Happiness < 0 || decay.Health < 0 {
+		return fmt.Errorf("species %s: decay multipliers can't be negative", def.Name)
+	}
+	ability := def.Ability
+	if ability.Name == "" {
+		return fmt.Errorf("species %s: ability has no name", def.Name)
+	}
+	if ability.Cooldown < 0 || ability.Duration < 0 || ability.HappinessDecay < 0 || ability.Uses < 0 {
+		return fmt.Errorf("species %s: ability parameters can't be negative", def.Name)
+	}
+	if ability.Revive && ability.Uses == 0 {
+		return fmt.Errorf("species %s: a reviving ability needs a number of uses", def.Name)
+	}
+	return nil
+}
+
+// builtinSpecies are the definitions that ship with the game
+//
+//go:embed species/*.json
+var builtinSpecies embed.FS
+
+var (
+	speciesMu sync.RWMutex
+	species   = map[string]*SpeciesDef{}
+)
+
+func init() {
+	entries, err := builtinSpecies.ReadDir("species")
+	if err != nil {
+		panic(err)
+	}
+	for _, entry := range entries {
+		data, err := builtinSpecies.ReadFile("species/" + entry.Name())
+		if err == nil {
+			err = addSpecies(data)
+		}
+		if err != nil {
+			panic(fmt.Sprintf("built-in species %s: %v", entry.Name(), err))
+		}
+	}
+}
+
+// LoadSpecies adds every species definition (*.json) in dir.
+// A missing dir has none.
+func LoadSpecies(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := addSpecies(data); err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+	}
+	return nil
+}
+
+// addSpecies decodes a definition and defines it
+func addSpecies(data []byte) error {
+	var def SpeciesDef
+	if err := json.Unmarshal(data, &def); err != nil {
+		return err
+	}
+	return DefineSpecies(def)
+}
+
+// DefineSpecies registers a species played by the Generic pet, so a species
+// can be added from Go without a file. Decay rates left at zero are 1, so is
+// the happiness decay of the ability.
+func DefineSpecies(def SpeciesDef) error {
+	def.Decay = def.Decay.orNormal()
+	if def.Ability.HappinessDecay == 0 {
+		def.Ability.HappinessDecay = 1
+	}
+	if err := def.validate(); err != nil {
+		return err
+	}
+	if _, ok := Lookup(def.Name); ok {
+		return fmt.Errorf("species %s is already registered", def.Name)
+	}
+
+	speciesMu.Lock()
+	species[def.Name] = &def
+	speciesMu.Unlock()
+
+	ability := def.Ability.Name
+	if def.Ability.Description != "" {
+		ability += " - " + def.Ability.Description
+	}
+	Register(Descriptor{
+		Name:    def.Name,
+		Blurb:   def.Blurb,
+		Ability: ability,
+		New: func(name, _ string, opts ...Option) Pet {
+			p, _ := NewPet(def.Name, name, opts...)
+			return p
+		},
+		Restore: restoreGeneric,
+	})
+	return nil
+}
+
+// Species returns the definition of a data driven species
+func Species(name string) (*SpeciesDef, bool) {
+	speciesMu.RLock()
+	defer speciesMu.RUnlock()
+	def, ok := species[name]
+	return def, ok
+}
@@ -0,0 +1,131 @@
+package pet
+
+import (
+	"VirtualPetGo/clock"
+	"encoding/json"
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func TestTimeScaleSpeedsUpEverything(t *testing.T) {
+	makePets := func(clk *clock.Manual) []Pet {
+		opts := func() []Option {
+			return []Option{WithClock(clk), WithRand(rand.New(rand.NewPCG(4, 4)))}
+		}
+		return []Pet{NewDog("Max", "Golden Retriever", opts()...), NewCat("Whiskers", "Orange", opts()...), NewBird("Tweety", "Canary", opts()...)}
+	}
+
+	// 30 real seconds at 10x...
+	fastClock := clock.NewManual(simulationStart)
+	fast := makePets(fastClock)
+	for _, p := range fast {
+		p.SetTimeScale(10)
+		p.UseSpecialAbility()
+	}
+	fastClock.Advance(30 * time.Second)
+	for _, p := range fast {
+		p.Update(30)
+	}
+
+	// ...are 300 seconds in real time
+	slowClock := clock.NewManual(simulationStart)
+	slow := makePets(slowClock)
+	for _, p := range slow {
+		p.UseSpecialAbility()
+	}
+	slowClock.Advance(300 * time.Second)
+	for _, p := range slow {
+		p.Update(300)
+	}
+
+	for i := range fast {
+		want, got := slow[i].GetStatus(), fast[i].GetStatus()
+		want.TimeScale, want.ActiveTime = got.TimeScale, got.ActiveTime
+		if got != want {
+			t.Errorf("%s at 10x differs from real time:\nwant %+v\ngot  %+v", want.Type, want, got)
+		}
+	}
+}
+
+func TestSetTimeScaleKeepsAge(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	basePet := newBasePet("TestPet", WithClock(clk))
+
+	clk.Advance(2 * time.Minute)
+	basePet.SetTimeScale(10)
+	if basePet.GetAge() != 2.0 {
+		t.Errorf("Expected age 2.0 right after the change, got %f", basePet.GetAge())
+	}
+
+	// 30 real seconds at 10x are 5 pet minutes
+	clk.Advance(30 * time.Second)
+	if basePet.GetAge() != 7.0 || basePet.getAgeStage() != Adult {
+		t.Errorf("Expected an Adult aged 7.0, got %f (%s)", basePet.GetAge(), basePet.getAgeStage())
+	}
+
+	basePet.SetTimeScale(100)
+	if basePet.TimeScale() != MaxTimeScale {
+		t.Errorf("Expected the scale clamped to %g, got %g", MaxTimeScale, basePet.TimeScale())
+	}
+}
+
+func TestLoyaltyLastsInPetTime(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	dog := NewDog("Max", "Golden Retriever", WithClock(clk))
+	dog.SetTimeScale(2)
+	dog.UseSpecialAbility()
+
+	// 20 real seconds are 40 of the 60 pet seconds, then time slows down
+	clk.Advance(20 * time.Second)
+	dog.Update(20)
+	dog.SetTimeScale(0.5)
+
+	// The remaining 20 pet seconds now take 40 real seconds
+	clk.Advance(39 * time.Second)
+	dog.Update(39)
+	if !dog.loyaltyActive {
+		t.Fatal("Loyalty should still be active")
+	}
+	clk.Advance(2 * time.Second)
+	dog.Update(2)
+	if dog.loyaltyActive {
+		t.Error("Loyalty should have run out")
+	}
+}
+
+func TestSnapshotKeepsTimeScale(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	dog := NewDog("Max", "Golden Retriever", WithClock(clk))
+	clk.Advance(time.Minute)
+	dog.SetTimeScale(4)
+	dog.UseSpecialAbility()
+	clk.Advance(time.Second)
+
+	restored, err := Restore(dog.Snapshot(), WithClock(clk))
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if restored.TimeScale() != 4 || restored.GetAge() != dog.GetAge() {
+		t.Errorf("Expected age %f at 4x, got %f at %gx", dog.GetAge(), restored.GetAge(), restored.TimeScale())
+	}
+
+	// At real time the pet is as old and has as much loyalty left
+	realTime := dog.Snapshot().Rescaled(clk.Now(), 1)
+	if age := clk.Now().Sub(realTime.BirthTime); age != 64*time.Second {
+		t.Errorf("Expected 64 seconds of age at 1x, got %s", age)
+	}
+	if left := realTime.Dog.LoyaltyEndTime.Sub(clk.Now()); left != 56*time.Second {
+		t.Errorf("Expected 56 seconds of loyalty left at 1x, got %s", left)
+	}
+
+	// A snapshot without a time scale is real time
+	old := dog.Snapshot()
+	old.TimeScale = 0
+	data, _ := json.Marshal(old)
+	var decoded Snapshot
+	json.Unmarshal(data, &decoded)
+	if p, _ := Restore(decoded); p.TimeScale() != 1 {
+		t.Errorf("Expected real time, got %gx", p.TimeScale())
+	}
+}
@@ -0,0 +1,145 @@
+package pet
+
+import (
+	"math/rand/v2"
+	"strings"
+)
+
+// Trait is a personality quirk a pet is born with
+type Trait struct {
+	Name        string
+	Description string
+	Decay       Decay             // Multiplies the decay rates
+	Illness     float64           // Multiplies the chance of falling ill
+	Actions     map[Action]Effect // Added to what the action does
+}
+
+// traits pets can be born with, in the order they are listed
+var traits = []Trait{
+	{
+		Name:        "Glutton",
+		Description: "Always hungry, lives for mealtime",
+		Decay:       Decay{Hunger: 1.3, Cleanliness: 1, Happiness: 1, Health: 1},
+		Illness:     1,
+		Actions: map[Action]Effect{
+			ActionFeed: {Happiness: 10, Message: "Gobbled up every crumb!"},
+		},
+	},
+	{
+		Name:        "Lazy",
+		Description: "Would rather nap than run around",
+		Decay:       Decay{Hunger: 0.9, Cleanliness: 1, Happiness: 1, Health: 1},
+		Illness:     1,
+		Actions: map[Action]Effect{
+			ActionPlay:  {Happiness: -8, Message: "Got bored quickly."},
+			ActionSleep: {Health: 10, Message: "Slept like a log!"},
+		},
+	},
+	{
+		Name:        "Neat",
+		Description: "Keeps itself tidy and hates dirt",
+		Decay:       Decay{Hunger: 1, Cleanliness: 0.7, Happiness: 1, Health: 1},
+		Illness:     0.7,
+		Actions: map[Action]Effect{
+			ActionClean: {Happiness: 10, Message: "Loves being spotless!"},
+		},
+	},
+	{
+		Name:        "Anxious",
+		Description: "Worries a lot and gets sick more easily",
+		Decay:       Decay{Hunger: 1, Cleanliness: 1, Happiness: 1.4, Health: 1},
+		Illness:     1.3,
+		Actions: map[Action]Effect{
+			ActionPlay: {Happiness: 5, Message: "Calmed down a little."},
+		},
+	},
+	{
+		Name:        "Hardy",
+		Description: "Tough as nails, rarely falls ill",
+		Decay:       Decay{Hunger: 1, Cleanliness: 1, Happiness: 1, Health: 0.8},
+		Illness:     0.5,
+	},
+}
+
+// Traits returns every trait a pet can be born with
+func Traits() []Trait {
+	return append([]Trait(nil), traits...)
+}
+
+// RollTraits picks two or three different traits for a newborn pet
+func RollTraits(r *rand.Rand) []string {
+	count := 2 + r.IntN(2)
+	var names []string
+	for _, i := range r.Perm(len(traits))[:count] {
+		names = append(names, traits[i].Name)
+	}
+	return names
+}
+
+// WithTraits gives the pet the named traits, unknown names are ignored
+func WithTraits(names ...string) Option {
+	return func(bp *BasePet) {
+		bp.traits = lookupTraits(names)
+	}
+}
+
+func lookupTraits(names []string) []Trait {
+	var found []Trait
+	for _, name := range names {
+		for _, t := range traits {
+			if t.Name == name {
+				found = append(found, t)
+			}
+		}
+	}
+	return found
+}
+
+// Traits returns the names of the pet's traits
+func (bp *BasePet) Traits() []string {
+	var names []string
+	for _, t := range bp.traits {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// traitText lists the traits for the status screen
+func (bp *BasePet) traitText() string {
+	return strings.Join(bp.Traits(), ", ")
+}
+
+// traitDecay multiplies the decay rates of every trait
+func (bp *BasePet) traitDecay() Decay {
+	decay := normalDecay
+	for _, t := range bp.traits {
+		decay = decay.times(t.Decay)
+	}
+	return decay
+}
+
+// illnessFactor multiplies the chance of falling ill of every trait
+func (bp *BasePet) illnessFactor() float64 {
+	factor := 1.0
+	for _, t := range bp.traits {
+		factor *= t.Illness
+	}
+	return factor
+}
+
+// applyTraits adds what the traits do to an action and returns their messages
+func (bp *BasePet) applyTraits(action Action) string {
+	var message string
+	for _, t := range bp.traits {
+		effect, ok := t.Actions[action]
+		if !ok {
+			continue
+		}
+		bp.setHealth(bp.GetHealth() + effect.Health)
+		bp.setHunger(bp.GetHunger() + effect.Hunger)
+		bp.setHappiness(bp.GetHappiness() + effect.Happiness)
+		bp.setCleanliness(bp.GetCleanliness() + effect.Cleanliness)
+		message += " (" + t.Name + ") " + effect.Message
+	}
+	return message
+}
@@ -0,0 +1,82 @@
+package pet
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestRollTraitsPicksTwoOrThreeDifferentTraits(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 1))
+	counts := map[int]bool{}
+	for i := 0; i < 50; i++ {
+		names := RollTraits(r)
+		counts[len(names)] = true
+
+		seen := map[string]bool{}
+		for _, name := range names {
+			if seen[name] || len(lookupTraits([]string{name})) != 1 {
+				t.Fatalf("Expected different known traits, got %v", names)
+			}
+			seen[name] = true
+		}
+	}
+	if len(counts) != 2 || !counts[2] || !counts[3] {
+		t.Errorf("Expected both two and three traits, got counts %v", counts)
+	}
+}
+
+func TestTraitsChangeDecay(t *testing.T) {
+	plain := newBasePet("Plain")
+	glutton := newBasePet("Glutton", WithTraits("Glutton"))
+
+	plain.Update(10)
+	glutton.Update(10)
+
+	// 26 hunger lost by a baby in 10 seconds, 1.3 times as much for a glutton (33.8)
+	if plain.GetHunger() != 74 || glutton.GetHunger() != 67 {
+		t.Errorf("Expected hunger 74 and 67, got %d and %d", plain.GetHunger(), glutton.GetHunger())
+	}
+}
+
+func TestTraitsChangeActions(t *testing.T) {
+	dog := NewDog("Max", "Golden Retriever", WithTraits("Lazy", "Glutton"))
+	dog.setHealth(50)
+	dog.setHappiness(50)
+
+	Perform(dog, ActionFeed)
+	if dog.GetHappiness() != 65 {
+		t.Errorf("A glutton should get 10 extra happiness from food, got %d", dog.GetHappiness())
+	}
+
+	message := Perform(dog, ActionSleep)
+	if dog.napHealth != 30 {
+		t.Errorf("A lazy pet should get 10 extra health from sleep, got %d", dog.napHealth)
+	}
+	if !strings.HasPrefix(message, "Max took a nice nap! Health Restored (Lazy) Slept like a log!") {
+		t.Errorf("Unexpected message %q", message)
+	}
+}
+
+func TestTraitsChangeIllnessChance(t *testing.T) {
+	hardy := newBasePet("Hardy", WithTraits("Hardy", "Anxious"))
+	if f := hardy.illnessFactor(); f != 0.65 {
+		t.Errorf("Expected illness factor 0.65, got %g", f)
+	}
+	plain := newBasePet("Plain")
+	if f := plain.illnessFactor(); f != 1 {
+		t.Errorf("A pet without traits should have factor 1, got %g", f)
+	}
+}
+
+func TestTraitsAreKeptOnRestore(t *testing.T) {
+	cat := NewCat("Whiskers", "Orange", WithTraits("Neat", "Hardy", "Anxious"))
+
+	restored, err := Restore(cat.Snapshot())
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if got := restored.GetStatus().Traits; got != "Neat, Hardy, Anxious" {
+		t.Errorf("Traits lost on restore, got %q", got)
+	}
+}
@@ -0,0 +1,108 @@
+package pet
+
+// WeightTrend is which way the weight went lately
+type WeightTrend string
+
+const (
+	Steady  WeightTrend = "Steady"
+	Gaining WeightTrend = "Gaining"
+	Losing  WeightTrend = "Losing"
+)
+
+func (bp *BasePet) GetWeight() int {
+	return bp.weight
+}
+
+// changeWeight adds to the weight and remembers which way it went
+func (bp *BasePet) changeWeight(amount int) {
+	before := bp.weight
+	bp.weight = clampStat(bp.weight + amount)
+	switch {
+	case bp.weight > before:
+		bp.trendFor = WeightTrendDuration
+	case bp.weight < before:
+		bp.trendFor = -WeightTrendDuration
+	}
+}
+
+// Trend returns which way the weight went in the last WeightTrendDuration
+func (bp *BasePet) Trend() WeightTrend {
+	switch {
+	case bp.trendFor > 0:
+		return Gaining
+	case bp.trendFor < 0:
+		return Losing
+	}
+	return Steady
+}
+
+// IsOverweight is true once the pet has been heavy for OverweightAfter
+func (bp *BasePet) IsOverweight() bool {
+	return bp.heavyFor >= OverweightAfter
+}
+
+// isFull is true right after a meal and when the pet isn't hungry at all
+func (bp *BasePet) isFull() bool {
+	return bp.satietyFor > 0 || bp.hunger >= FullHunger
+}
+
+// overfeed is what eating on a full stomach does, it may upset the stomach
+func (bp *BasePet) overfeed() string {
+	bp.changeWeight(OverfeedWeight)
+	message := " " + bp.GetName() + " was already full and put on weight!"
+	if !bp.isIll && bp.rng.Float64() < StomachBugChance {
+		bp.fallIll("Stomach Bug")
+		message += " Too much food gave them a Stomach Bug!"
+	}
+	return message
+}
+
+// fed starts the satiety window of a meal
+func (bp *BasePet) fed() {
+	bp.satietyFor = SatietyDuration
+}
+
+// applyWeight makes play and rest less effective for an overweight pet
+func (bp *BasePet) applyWeight(action Action) string {
+	switch action {
+	case ActionPlay:
+		if bp.IsOverweight() {
+			bp.setHappiness(bp.GetHappiness() - OverweightPlayPenalty)
+			return " (Overweight) Got out of breath quickly."
+		}
+		// Playing burns off extra weight
+		if bp.weight > IdealWeight {
+			bp.changeWeight(-PlayWeightLoss)
+		}
+	case ActionSleep:
+		if bp.IsOverweight() {
+			bp.setHealth(bp.GetHealth() - OverweightRestPenalty)
+			return " (Overweight) Didn't rest well."
+		}
+	}
+	return ""
+}
+
+// digest counts down the satiety window and the weight trend, and slims
+// down a hungry pet that is above its ideal weight
+func (bp *BasePet) digest(dt float64) {
+	bp.satietyFor = countDown(bp.satietyFor, dt)
+	switch {
+	case bp.trendFor > 0:
+		bp.trendFor = countDown(bp.trendFor, dt)
+	case bp.trendFor < 0:
+		bp.trendFor = -countDown(-bp.trendFor, dt)
+	}
+
+	if bp.hunger < LeanHunger && bp.weight > IdealWeight {
+		if lost := drain(&bp.sim.weight, WeightLossRate*dt); lost > 0 {
+			bp.changeWeight(-lost)
+		}
+	}
+
+	if bp.weight >= HeavyWeight {
+		bp.heavyFor += dt
+	} else {
+		bp.heavyFor = 0
+	}
+}
@@ -0,0 +1,119 @@
+package pet
+
+import (
+	"VirtualPetGo/clock"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFeedingAFullPetAddsWeight(t *testing.T) {
+	dog := NewDog("Max", "Golden Retriever", WithRand(rand.New(rand.NewPCG(1, 1))))
+	dog.setHunger(50)
+
+	Perform(dog, ActionFeed)
+	if dog.GetWeight() != IdealWeight {
+		t.Errorf("A hungry pet should not put on weight, got %d", dog.GetWeight())
+	}
+
+	// Still full from the last meal
+	message := Perform(dog, ActionFeed)
+	if dog.GetWeight() != IdealWeight+OverfeedWeight || dog.Trend() != Gaining {
+		t.Errorf("Expected weight %d and gaining, got %d %s", IdealWeight+OverfeedWeight, dog.GetWeight(), dog.Trend())
+	}
+	if !strings.Contains(message, "already full") {
+		t.Errorf("Expected an overfeeding message, got %q", message)
+	}
+}
+
+func TestOverfeedingCanCauseAStomachBug(t *testing.T) {
+	sick := 0
+	for seed := uint64(0); seed < 40; seed++ {
+		cat := NewCat("Whiskers", "Orange", WithRand(rand.New(rand.NewPCG(seed, seed))))
+		Perform(cat, ActionFeed)
+		if cat.IsIll() {
+			if cat.GetIllness() != "Stomach Bug" {
+				t.Fatalf("Expected a Stomach Bug, got %s", cat.GetIllness())
+			}
+			sick++
+		}
+	}
+	if sick == 0 || sick == 40 {
+		t.Errorf("Expected some overfed cats to get ill, got %d of 40", sick)
+	}
+}
+
+func TestSatietyWearsOff(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	dog := NewDog("Max", "Golden Retriever", WithClock(clk))
+	dog.setHunger(20)
+	Perform(dog, ActionFeed)
+
+	clk.Advance(time.Duration(SatietyDuration) * time.Second)
+	dog.Update(SatietyDuration)
+	if dog.isFull() {
+		t.Error("A pet should be hungry again after the satiety window")
+	}
+}
+
+func TestOverweightPetPlaysAndRestsWorse(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	dog := NewDog("Max", "Golden Retriever", WithClock(clk), WithRand(rand.New(rand.NewPCG(1, 1))))
+	dog.weight = HeavyWeight + 5
+
+	clk.Advance(time.Duration(OverweightAfter) * time.Second)
+	dog.Update(OverweightAfter)
+	if !dog.IsOverweight() || !dog.GetStatus().Overweight {
+		t.Fatal("Expected the dog to be overweight")
+	}
+
+	dog.setHappiness(20)
+	dog.setHealth(50)
+	Perform(dog, ActionPlay)
+	// Base play 20, fetch 20, less 10 for being out of breath
+	if dog.GetHappiness() != 50 {
+		t.Errorf("Expected happiness 50, got %d", dog.GetHappiness())
+	}
+	// The penalty stays even if the nap is cut short
+	Perform(dog, ActionSleep)
+	Perform(dog, ActionInteract)
+	if dog.GetHealth() != 50-OverweightRestPenalty {
+		t.Errorf("Expected the rest penalty to stay after waking, got %d", dog.GetHealth())
+	}
+
+	// A full nap restores only 10 health
+	dog.groggyFor = 0
+	dog.setHealth(50)
+	Perform(dog, ActionSleep)
+	if dog.GetHealth()+dog.napHealth != 60 {
+		t.Errorf("Expected sleep to restore only 10 health, got %d", dog.GetHealth()+dog.napHealth-50)
+	}
+}
+
+func TestHungryPetSlimsDown(t *testing.T) {
+	clk := clock.NewManual(simulationStart)
+	bird := NewBird("Tweety", "Canary", WithClock(clk))
+	bird.weight = 60
+	bird.setHunger(0)
+
+	clk.Advance(100 * time.Second)
+	bird.Update(100)
+
+	if bird.GetWeight() != 55 || bird.Trend() != Losing {
+		t.Errorf("Expected weight 55 and losing, got %d %s", bird.GetWeight(), bird.Trend())
+	}
+}
+
+func TestWeightIsKeptOnRestore(t *testing.T) {
+	dog := NewDog("Max", "Golden Retriever")
+	Perform(dog, ActionFeed)
+
+	restored, err := Restore(dog.Snapshot())
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if got, want := restored.GetStatus(), dog.GetStatus(); got.Weight != want.Weight || got.WeightTrend != Gaining {
+		t.Errorf("Weight lost on restore, got %d %s", got.Weight, got.WeightTrend)
+	}
+}
@@ -0,0 +1,32 @@
+package save
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomicReplacesAndCleansUp(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "slot.json")
+
+	if err := writeFileAtomic(path, []byte("first")); err != nil {
+		t.Fatalf("First write failed: %v", err)
+	}
+	if err := writeFileAtomic(path, []byte("second")); err != nil {
+		t.Fatalf("Second write failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Reading back failed: %v", err)
+	}
+	if string(data) != "second" {
+		t.Errorf("Expected 'second', got '%s'", data)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("Temp files were left behind: %v", entries)
+	}
+}
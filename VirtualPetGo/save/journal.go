@@ -0,0 +1,292 @@
+package save
+
+import (
+	"VirtualPetGo/pet"
+	"bytes"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// EntryKind says what a journal entry records
+type EntryKind string
+
+const (
+	// EntryCheckpoint holds the full pet and the state of its random source,
+	// a replay starts from one. The game adds one every time it saves.
+	EntryCheckpoint EntryKind = "checkpoint"
+	// EntryUpdate is one Update call on the pet
+	EntryUpdate EntryKind = "update"
+	// EntryAction is one main menu choice handled by the game
+	EntryAction EntryKind = "action"
+	// EntryOffline is the catch-up on time spent away when a save is loaded
+	EntryOffline EntryKind = "offline"
+	// EntryTimeScale is a change of the time scale from the settings menu
+	EntryTimeScale EntryKind = "time_scale"
+)
+
+// Entry is one line of a journal
+type Entry struct {
+	Seq  int       `json:"seq"`
+	At   time.Time `json:"at"` // Game clock when it happened
+	Kind EntryKind `json:"kind"`
+
+	Elapsed float64       `json:"elapsed,omitempty"` // Update: seconds passed to Update
+	Choice  int           `json:"choice,omitempty"`  // Action: main menu choice
+	Action  pet.Action    `json:"action,omitempty"`  // Action: what it did to the pet, empty if nothing
+	Item    string        `json:"item,omitempty"`    // Action: the food fed, empty for a standard meal
+	Away    time.Duration `json:"away,omitempty"`    // Offline: time since the save
+	Limit   time.Duration `json:"limit,omitempty"`   // Offline: cap on the simulated time
+	Scale   float64       `json:"scale,omitempty"`   // TimeScale: the new time scale
+
+	Delta      *Delta      `json:"delta,omitempty"` // Stat changes the entry caused, nil if none
+	Checkpoint *Checkpoint `json:"checkpoint,omitempty"`
+
+	// HMAC of the entry chained to the signature of the one before it, see sign.go
+	Signature string `json:"signature,omitempty"`
+
+	// Verified is set by ReadJournal when the signature chain holds up to this entry
+	Verified bool `json:"-"`
+
+	size int // Length of the entry's line, set by readJournal
+}
+
+// Checkpoint is a point a replay can start from
+type Checkpoint struct {
+	Pet  pet.Snapshot `json:"pet"`
+	Rand []byte       `json:"rand"` // The rand.PCG the pet draws from, as MarshalBinary
+}
+
+// Delta is how much the core stats changed
+type Delta struct {
+	Health      int `json:"health,omitempty"`
+	Hunger      int `json:"hunger,omitempty"`
+	Happiness   int `json:"happiness,omitempty"`
+	Cleanliness int `json:"cleanliness,omitempty"`
+	Energy      int `json:"energy,omitempty"`
+	Weight      int `json:"weight,omitempty"`
+	Coins       int `json:"coins,omitempty"`
+}
+
+// StatDelta returns the change from before to after, nil if nothing changed
+func StatDelta(before, after pet.Status) *Delta {
+	delta := Delta{
+		Health:      after.Health - before.Health,
+		Hunger:      after.Hunger - before.Hunger,
+		Happiness:   after.Happiness - before.Happiness,
+		Cleanliness: after.Cleanliness - before.Cleanliness,
+		Energy:      after.Energy - before.Energy,
+		Weight:      after.Weight - before.Weight,
+		Coins:       after.Coins - before.Coins,
+	}
+	if delta == (Delta{}) {
+		return nil
+	}
+	return &delta
+}
+
+// journalLimit is the size a journal grows to before Compact drops its oldest
+// entries, about half of it is kept
+var journalLimit int64 = 4 << 20
+
+// Journal appends entries to a journal file
+type Journal struct {
+	path string
+	file *os.File
+	seq  int
+	last string // Signature of the last entry, the next one is chained to it
+}
+
+// OpenJournal opens the journal at path for appending, creating it if needed.
+// A line left half written by a crash at the end of the file is cut off first.
+func OpenJournal(path string) (*Journal, error) {
+	entries, complete, err := readJournal(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening journal: %w", err)
+	}
+	if err := file.Truncate(complete); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("repairing journal: %w", err)
+	}
+	if _, err := file.Seek(complete, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("opening journal: %w", err)
+	}
+
+	journal := &Journal{path: path, file: file}
+	if n := len(entries); n > 0 {
+		journal.seq = entries[n-1].Seq
+		journal.last = entries[n-1].Signature
+	}
+	return journal, nil
+}
+
+// Append signs the entry, writes it as one line and syncs it to disk.
+// Seq and Signature are filled in by the journal.
+func (j *Journal) Append(entry Entry) error {
+	entry.Seq = j.seq + 1
+	entry, line, err := signEntry(entry, j.last)
+	if err != nil {
+		return err
+	}
+	if _, err := j.file.Write(line); err != nil {
+		return fmt.Errorf("writing journal: %w", err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("writing journal: %w", err)
+	}
+
+	j.seq = entry.Seq
+	j.last = entry.Signature
+	return nil
+}
+
+// Compact drops the oldest entries once the journal is over journalLimit. What
+// is kept starts at a checkpoint, so the pet can still be rebuilt from the
+// journal, and the signature chain starts again there. Entries that didn't
+// verify are kept as they were, compacting doesn't make them verify.
+func (j *Journal) Compact() error {
+	info, err := j.file.Stat()
+	if err != nil {
+		return fmt.Errorf("reading journal: %w", err)
+	}
+	if info.Size() <= journalLimit {
+		return nil
+	}
+	entries, _, err := readJournal(j.path)
+	if err != nil {
+		return err
+	}
+
+	// Keep from the oldest checkpoint that fits in half the limit, or the last one
+	start := -1
+	var kept int64
+	for i := len(entries) - 1; i >= 0; i-- {
+		kept += int64(entries[i].size)
+		if entries[i].Kind != EntryCheckpoint {
+			continue
+		}
+		if start >= 0 && kept > journalLimit/2 {
+			break
+		}
+		start = i
+	}
+	if start <= 0 {
+		return nil
+	}
+
+	var data []byte
+	previous := ""
+	for _, entry := range entries[start:] {
+		var line []byte
+		if entry.Verified {
+			entry, line, err = signEntry(entry, previous)
+		} else {
+			line, err = json.Marshal(entry)
+			line = append(line, '\n')
+		}
+		if err != nil {
+			return fmt.Errorf("encoding journal entry: %w", err)
+		}
+		data = append(data, line...)
+		previous = entry.Signature
+	}
+	if err := writeFileAtomic(j.path, data); err != nil {
+		return fmt.Errorf("writing journal: %w", err)
+	}
+
+	// The old file was replaced, carry on appending to the new one
+	file, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("opening journal: %w", err)
+	}
+	j.file.Close()
+	j.file = file
+	j.last = previous
+	return nil
+}
+
+// signEntry signs the entry chained to previous, it returns the entry and its line
+func signEntry(entry Entry, previous string) (Entry, []byte, error) {
+	entry.Signature = ""
+
+	doc, err := toDocument(entry)
+	if err != nil {
+		return entry, nil, fmt.Errorf("encoding journal entry: %w", err)
+	}
+	unsigned, err := doc.unsignedJSON()
+	if err != nil {
+		return entry, nil, fmt.Errorf("encoding journal entry: %w", err)
+	}
+	entry.Signature = keyedHash([]byte(previous), unsigned)
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return entry, nil, fmt.Errorf("encoding journal entry: %w", err)
+	}
+	return entry, append(line, '\n'), nil
+}
+
+func (j *Journal) Close() error {
+	return j.file.Close()
+}
+
+// ReadJournal reads every complete entry of a journal, a missing journal has none
+func ReadJournal(path string) ([]Entry, error) {
+	entries, _, err := readJournal(path)
+	return entries, err
+}
+
+// readJournal also returns the length of the file up to the last complete line
+func readJournal(path string) ([]Entry, int64, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading journal: %w", err)
+	}
+
+	var entries []Entry
+	var complete int64
+	previous := ""
+	chained := true
+	for {
+		end := bytes.IndexByte(data, '\n')
+		if end < 0 {
+			// Whatever is left was cut off by a crash while writing
+			break
+		}
+		line := data[:end]
+
+		var doc document
+		if err := json.Unmarshal(line, &doc); err != nil {
+			return nil, 0, fmt.Errorf("journal entry %d: %w", len(entries)+1, err)
+		}
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, 0, fmt.Errorf("journal entry %d: %w", len(entries)+1, err)
+		}
+
+		unsigned, err := doc.unsignedJSON()
+		expected := keyedHash([]byte(previous), unsigned)
+		chained = chained && err == nil && hmac.Equal([]byte(entry.Signature), []byte(expected))
+		entry.Verified = chained
+		entry.size = end + 1
+		previous = entry.Signature
+
+		entries = append(entries, entry)
+		complete += int64(end + 1)
+		data = data[end+1:]
+	}
+	return entries, complete, nil
+}
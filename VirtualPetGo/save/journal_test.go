@@ -0,0 +1,196 @@
+package save
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJournalAppendAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pet.journal")
+	journal, err := OpenJournal(path)
+	if err != nil {
+		t.Fatalf("OpenJournal failed: %v", err)
+	}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	journal.Append(Entry{At: start, Kind: EntryUpdate, Elapsed: 1, Delta: &Delta{Hunger: -1}})
+	journal.Append(Entry{At: start.Add(time.Second), Kind: EntryAction, Choice: 1, Action: "feed"})
+	journal.Close()
+
+	// Reopening carries on the sequence and the signature chain
+	journal, err = OpenJournal(path)
+	if err != nil {
+		t.Fatalf("OpenJournal failed: %v", err)
+	}
+	journal.Append(Entry{At: start.Add(2 * time.Second), Kind: EntryUpdate, Elapsed: 1})
+	journal.Close()
+
+	entries, err := ReadJournal(path)
+	if err != nil {
+		t.Fatalf("ReadJournal failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	for i, entry := range entries {
+		if entry.Seq != i+1 {
+			t.Errorf("Entry %d has seq %d", i, entry.Seq)
+		}
+		if !entry.Verified {
+			t.Errorf("Entry %d should verify", entry.Seq)
+		}
+	}
+	if entries[0].Delta == nil || entries[0].Delta.Hunger != -1 || entries[1].Action != "feed" {
+		t.Errorf("Entries not kept: %+v", entries)
+	}
+}
+
+func TestJournalDropsTornLastLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pet.journal")
+	journal, _ := OpenJournal(path)
+	journal.Append(Entry{At: time.Now(), Kind: EntryUpdate, Elapsed: 1})
+	journal.Close()
+
+	// A crash in the middle of writing the next line
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	file.WriteString(`{"seq":2,"at":"2024-01`)
+	file.Close()
+
+	entries, err := ReadJournal(path)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Expected the complete entry only, got %d entries, err %v", len(entries), err)
+	}
+
+	// Appending again repairs the file first
+	journal, err = OpenJournal(path)
+	if err != nil {
+		t.Fatalf("OpenJournal failed: %v", err)
+	}
+	journal.Append(Entry{At: time.Now(), Kind: EntryUpdate, Elapsed: 1})
+	journal.Close()
+
+	entries, err = ReadJournal(path)
+	if err != nil || len(entries) != 2 || !entries[1].Verified {
+		t.Errorf("Expected 2 verified entries after the repair, got %+v, err %v", entries, err)
+	}
+}
+
+func TestJournalEditBreaksTheChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pet.journal")
+	journal, _ := OpenJournal(path)
+	for i := 0; i < 3; i++ {
+		journal.Append(Entry{At: time.Now(), Kind: EntryUpdate, Elapsed: 1, Delta: &Delta{Hunger: -1}})
+	}
+	journal.Close()
+
+	data, _ := os.ReadFile(path)
+	lines := strings.SplitAfter(string(data), "\n")
+	lines[1] = strings.Replace(lines[1], `"hunger":-1`, `"hunger":5`, 1)
+	os.WriteFile(path, []byte(strings.Join(lines, "")), 0644)
+
+	entries, err := ReadJournal(path)
+	if err != nil {
+		t.Fatalf("ReadJournal failed: %v", err)
+	}
+	if !entries[0].Verified || entries[1].Verified || entries[2].Verified {
+		t.Errorf("Only the entries before the edit should verify: %v %v %v",
+			entries[0].Verified, entries[1].Verified, entries[2].Verified)
+	}
+}
+
+func TestReadMissingJournal(t *testing.T) {
+	entries, err := ReadJournal(filepath.Join(t.TempDir(), "missing.journal"))
+	if err != nil || entries != nil {
+		t.Errorf("A missing journal should read as empty, got %v, %v", entries, err)
+	}
+}
+
+// writeRounds appends rounds of a checkpoint followed by 5 updates
+func writeRounds(t *testing.T, journal *Journal, rounds int) {
+	t.Helper()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for round := 0; round < rounds; round++ {
+		at := start.Add(time.Duration(round) * time.Minute)
+		journal.Append(Entry{At: at, Kind: EntryCheckpoint, Checkpoint: &Checkpoint{}})
+		for i := 1; i <= 5; i++ {
+			journal.Append(Entry{At: at.Add(time.Duration(i) * time.Second), Kind: EntryUpdate, Elapsed: 1})
+		}
+	}
+}
+
+func TestJournalCompactKeepsSmallJournals(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pet.journal")
+	journal, _ := OpenJournal(path)
+	writeRounds(t, journal, 4)
+	if err := journal.Compact(); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	journal.Close()
+
+	entries, _ := ReadJournal(path)
+	if len(entries) != 24 {
+		t.Errorf("A journal under the limit should keep everything, got %d entries", len(entries))
+	}
+}
+
+func TestJournalCompactDropsTheOldestEntries(t *testing.T) {
+	limit := journalLimit
+	t.Cleanup(func() { journalLimit = limit })
+
+	path := filepath.Join(t.TempDir(), "pet.journal")
+	journal, _ := OpenJournal(path)
+	writeRounds(t, journal, 10)
+	info, _ := os.Stat(path)
+	journalLimit = info.Size() / 2
+
+	if err := journal.Compact(); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	journal.Append(Entry{At: time.Now(), Kind: EntryUpdate, Elapsed: 1})
+	journal.Close()
+
+	entries, err := ReadJournal(path)
+	if err != nil {
+		t.Fatalf("ReadJournal failed: %v", err)
+	}
+	// Half the limit is a quarter of the journal, the last 2 rounds and a bit
+	if len(entries) != 13 || entries[0].Kind != EntryCheckpoint || entries[0].Seq != 49 || entries[12].Seq != 61 {
+		t.Fatalf("Expected the last 2 rounds from a checkpoint, got %d entries from seq %d", len(entries), entries[0].Seq)
+	}
+	for _, entry := range entries {
+		if !entry.Verified {
+			t.Errorf("Entry %d of the compacted journal should verify", entry.Seq)
+		}
+	}
+}
+
+func TestJournalCompactKeepsEditsUnverified(t *testing.T) {
+	limit := journalLimit
+	t.Cleanup(func() { journalLimit = limit })
+
+	path := filepath.Join(t.TempDir(), "pet.journal")
+	journal, _ := OpenJournal(path)
+	writeRounds(t, journal, 10)
+	journal.Close()
+
+	data, _ := os.ReadFile(path)
+	lines := strings.SplitAfter(string(data), "\n")
+	lines[58] = strings.Replace(lines[58], `"elapsed":1`, `"elapsed":2`, 1)
+	os.WriteFile(path, []byte(strings.Join(lines, "")), 0644)
+	journalLimit = int64(len(data)) / 2
+
+	journal, _ = OpenJournal(path)
+	if err := journal.Compact(); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	journal.Close()
+
+	entries, _ := ReadJournal(path)
+	for _, entry := range entries {
+		if want := entry.Seq < 59; entry.Verified != want {
+			t.Errorf("Entry %d: expected verified %v, got %v", entry.Seq, want, entry.Verified)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package save
+
+import (
+	"VirtualPetGo/interop"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// CurrentVersion is the save format this build writes.
+// Bump it together with a new entry in migrations and a testdata/v<N>.json fixture.
+const CurrentVersion = 15
+
+// signedVersion is the first save version the game signed
+const signedVersion = 3
+
+var ErrTooNew = errors.New("save was made by a newer version of the game")
+
+// document is a save file decoded without a schema, so migrations can reshape it
+type document map[string]any
+
+// migrations upgrade a document from the version they are keyed by to the next one
+var migrations = map[int]func(document) error{
+	1:  migrateV1ToV2,
+	2:  migrateV2ToV3,
+	3:  migrateV3ToV4,
+	4:  migrateV4ToV5,
+	5:  migrateV5ToV6,
+	6:  migrateV6ToV7,
+	7:  migrateV7ToV8,
+	8:  migrateV8ToV9,
+	9:  migrateV9ToV10,
+	10: migrateV10ToV11,
+	11: migrateV11ToV12,
+	12: migrateV12ToV13,
+	13: migrateV13ToV14,
+	14: migrateV14ToV15,
+}
+
+// Decode parses save data of any known version and upgrades it to CurrentVersion.
+// Files in the format shared with the C# game are read as well.
+func Decode(data []byte) (*File, error) {
+	if interop.IsShared(data) {
+		snapshot, savedAt, err := interop.Decode(data)
+		if err != nil {
+			return nil, err
+		}
+		return &File{Version: CurrentVersion, SavedAt: savedAt, Pet: snapshot, Legacy: true}, nil
+	}
+
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	version, err := doc.version()
+	if err != nil {
+		return nil, err
+	}
+	// Checked on the file as written, before migrations change it
+	verified := doc.verify()
+	legacy := version < signedVersion
+	if version > CurrentVersion {
+		return nil, fmt.Errorf("%w (file is version %d, this game reads up to version %d)",
+			ErrTooNew, version, CurrentVersion)
+	}
+
+	for ; version < CurrentVersion; version++ {
+		migrate, ok := migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("no migration from save version %d", version)
+		}
+		if err := migrate(doc); err != nil {
+			return nil, fmt.Errorf("migrating save from version %d: %w", version, err)
+		}
+		doc["version"] = version + 1
+	}
+
+	// Round trip through JSON to get the typed form
+	upgraded, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var file File
+	if err := json.Unmarshal(upgraded, &file); err != nil {
+		return nil, err
+	}
+	file.Verified = verified
+	file.Legacy = legacy
+	return &file, nil
+}
+
+// version reads the schema version, files from before versioning count as 1
+func (doc document) version() (int, error) {
+	raw, ok := doc["version"]
+	if !ok {
+		return 1, nil
+	}
+	number, ok := raw.(float64)
+	if !ok || number != float64(int(number)) || number < 1 {
+		return 0, fmt.Errorf("invalid save version %v", raw)
+	}
+	return int(number), nil
+}
+
+// object returns the nested object at key, or an error if it isn't one
+func (doc document) object(key string) (document, error) {
+	value, ok := doc[key].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("missing %q object", key)
+	}
+	return value, nil
+}
+
+// migrateV1ToV2 adds the fixed timestep state that unversioned saves didn't have
+func migrateV1ToV2(doc document) error {
+	p, err := doc.object("pet")
+	if err != nil {
+		return err
+	}
+	if _, ok := p["simulation"]; !ok {
+		p["simulation"] = map[string]any{
+			"pending_seconds":       0.0,
+			"illness_ticks":         0,
+			"hunger_remainder":      0.0,
+			"cleanliness_remainder": 0.0,
+			"happiness_remainder":   0.0,
+			"health_remainder":      0.0,
+		}
+	}
+	return nil
+}
+
+// migrateV2ToV3 has nothing to reshape, version 3 added the signature and the
+// modified flag. Older saves have no signature, they load as legacy saves.
+func migrateV2ToV3(doc document) error {
+	return nil
+}
+
+// migrateV3ToV4 adds the time scale, older saves were all played in real time
+func migrateV3ToV4(doc document) error {
+	p, err := doc.object("pet")
+	if err != nil {
+		return err
+	}
+	if _, ok := p["time_scale"]; !ok {
+		p["time_scale"] = 1.0
+	}
+	return nil
+}
+
+// migrateV4ToV5 adds the pause state and play time. Older saves were never
+// paused and didn't count play time, so it starts from zero.
+func migrateV4ToV5(doc document) error {
+	p, err := doc.object("pet")
+	if err != nil {
+		return err
+	}
+	for _, key := range []string{"paused_seconds", "active_seconds"} {
+		if _, ok := p[key]; !ok {
+			p[key] = 0.0
+		}
+	}
+	return nil
+}
+
+// migrateV5ToV6 has nothing to reshape, version 6 added data driven species
+// with their state under "generic". Older saves only have the built in ones.
+func migrateV5ToV6(doc document) error {
+	return nil
+}
+
+// migrateV6ToV7 has nothing to reshape either, version 7 added the Fish with
+// its state under "fish"
+func migrateV6ToV7(doc document) error {
+	return nil
+}
+
+// defaultBreeds are what pets were before version 8 added breeds
+var defaultBreeds = map[string]string{
+	"Dog":  "Golden Retriever",
+	"Cat":  "Orange",
+	"Bird": "Canary",
+}
+
+func migrateV7ToV8(doc document) error {
+	p, err := doc.object("pet")
+	if err != nil {
+		return err
+	}
+	if breed, ok := defaultBreeds[fmt.Sprint(p["type"])]; ok {
+		if _, ok := p["breed"]; !ok {
+			p["breed"] = breed
+		}
+	}
+	return nil
+}
+
+// migrateV8ToV9 has nothing to reshape, version 9 added personality traits
+// and pets from before them have none
+func migrateV8ToV9(doc document) error {
+	return nil
+}
+
+// migrateV9ToV10 adds energy, pets from before it are fully rested and awake
+func migrateV9ToV10(doc document) error {
+	p, err := doc.object("pet")
+	if err != nil {
+		return err
+	}
+	if _, ok := p["energy"]; !ok {
+		p["energy"] = 100.0
+	}
+	return nil
+}
+
+// migrateV10ToV11 adds weight, pets from before it are at their ideal weight
+func migrateV10ToV11(doc document) error {
+	p, err := doc.object("pet")
+	if err != nil {
+		return err
+	}
+	if _, ok := p["weight"]; !ok {
+		p["weight"] = 50.0
+	}
+	return nil
+}
+
+// migrateV11ToV12 adds the food pantry, pets from before it get the one new
+// pets start with
+func migrateV11ToV12(doc document) error {
+	p, err := doc.object("pet")
+	if err != nil {
+		return err
+	}
+	if _, ok := p["pantry"]; !ok {
+		p["pantry"] = map[string]any{"Kibble": 10.0, "Tuna": 3.0, "Seeds": 10.0, "Treats": 5.0}
+	}
+	return nil
+}
+
+// migrateV12ToV13 adds coins, pets from before them get the coins new pets
+// start with. Their age stage at load counts as reached, see pet.Snapshot.Milestone.
+func migrateV12ToV13(doc document) error {
+	p, err := doc.object("pet")
+	if err != nil {
+		return err
+	}
+	if _, ok := p["coins"]; !ok {
+		p["coins"] = 20.0
+	}
+	return nil
+}
+
+// migrateV13ToV14 replaces the Medicine that cured any illness with a medicine
+// for each illness. Medicine bought before is refunded at its old price.
+func migrateV13ToV14(doc document) error {
+	p, err := doc.object("pet")
+	if err != nil {
+		return err
+	}
+	supplies, _ := p["supplies"].(map[string]any)
+	count, ok := supplies["Medicine"].(float64)
+	if !ok {
+		return nil
+	}
+	coins, _ := p["coins"].(float64)
+	p["coins"] = coins + 15*count
+	delete(supplies, "Medicine")
+	return nil
+}
+
+// migrateV14ToV15 has nothing to reshape, version 15 holds the health of a
+// nap back until it ends. Older saves already got it when the nap started.
+func migrateV14ToV15(doc document) error {
+	return nil
+}
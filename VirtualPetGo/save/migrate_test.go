@@ -0,0 +1,230 @@
+package save
+
+import (
+	"VirtualPetGo/pet"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fixtureChecks holds what each historical save version in testdata must load as
+var fixtureChecks = map[int]func(t *testing.T, s pet.Snapshot){
+	1: func(t *testing.T, s pet.Snapshot) {
+		if s.Type != "Cat" || s.Name != "Whiskers" {
+			t.Errorf("Expected Whiskers the Cat, got %s the %s", s.Name, s.Type)
+		}
+		if s.Hunger != 45 || s.Cleanliness != 25 || !s.IsIll || s.IllnessName != "Fleas" {
+			t.Errorf("Stats not kept: %+v", s)
+		}
+		if s.Cat == nil || s.Cat.LivesRemaining != 7 {
+			t.Errorf("Expected 7 lives, got %+v", s.Cat)
+		}
+		if s.Simulation != (pet.SimulationState{}) {
+			t.Errorf("Version 1 should migrate to empty simulation state, got %+v", s.Simulation)
+		}
+	},
+	2: func(t *testing.T, s pet.Snapshot) {
+		if s.Type != "Dog" || s.Name != "Max" {
+			t.Errorf("Expected Max the Dog, got %s the %s", s.Name, s.Type)
+		}
+		if s.Dog == nil || !s.Dog.LoyaltyActive {
+			t.Errorf("Expected active loyalty, got %+v", s.Dog)
+		}
+		if s.Simulation.IllnessTicks != 12 || s.Simulation.HungerRemainder != 0.4 {
+			t.Errorf("Simulation state not kept: %+v", s.Simulation)
+		}
+	},
+	3: func(t *testing.T, s pet.Snapshot) {
+		if s.Type != "Bird" || s.Name != "Tweety" {
+			t.Errorf("Expected Tweety the Bird, got %s the %s", s.Name, s.Type)
+		}
+		if s.Bird == nil || s.Bird.SongCooldown != 12.5 || s.Bird.SongsPerformed != 4 {
+			t.Errorf("Bird state not kept: %+v", s.Bird)
+		}
+		if s.Modified {
+			t.Error("Version 3 fixture should not be marked as modified")
+		}
+		if s.TimeScale != 1 {
+			t.Errorf("Version 3 should migrate to real time, got %gx", s.TimeScale)
+		}
+	},
+	4: func(t *testing.T, s pet.Snapshot) {
+		if s.Type != "Dog" || s.Name != "Rex" {
+			t.Errorf("Expected Rex the Dog, got %s the %s", s.Name, s.Type)
+		}
+		if s.TimeScale != 2.5 {
+			t.Errorf("Expected 2.5x, got %gx", s.TimeScale)
+		}
+		if s.Dog == nil || !s.Dog.LoyaltyActive {
+			t.Errorf("Expected active loyalty, got %+v", s.Dog)
+		}
+		if !s.PausedAt.IsZero() || s.ActiveSeconds != 0 {
+			t.Errorf("Version 4 should migrate to running with no play time, got %+v", s)
+		}
+	},
+	5: func(t *testing.T, s pet.Snapshot) {
+		if s.Type != "Cat" || s.Name != "Luna" {
+			t.Errorf("Expected Luna the Cat, got %s the %s", s.Name, s.Type)
+		}
+		if s.PausedAt.IsZero() || s.PausedSeconds != 240 || s.ActiveSeconds != 510.5 {
+			t.Errorf("Pause state not kept: %+v", s)
+		}
+		if s.Breed != "Orange" {
+			t.Errorf("Cats from before breeds should be Orange, got %q", s.Breed)
+		}
+	},
+	6: func(t *testing.T, s pet.Snapshot) {
+		if s.Type != "Hamster" || s.Name != "Nibbles" {
+			t.Errorf("Expected Nibbles the Hamster, got %s the %s", s.Name, s.Type)
+		}
+		if s.Generic == nil || s.Generic.Cooldown != 42.5 || s.Generic.TimesUsed != 2 {
+			t.Errorf("Species state not kept: %+v", s.Generic)
+		}
+	},
+	7: func(t *testing.T, s pet.Snapshot) {
+		if s.Type != "Fish" || s.Name != "Bubbles" {
+			t.Errorf("Expected Bubbles the Fish, got %s the %s", s.Name, s.Type)
+		}
+		if s.Cleanliness != 34 || s.Fish == nil || s.Fish.FilterCooldown != 75 {
+			t.Errorf("Tank state not kept: %+v %+v", s, s.Fish)
+		}
+		if s.Breed != "" {
+			t.Errorf("Fish have no breeds, got %q", s.Breed)
+		}
+	},
+	8: func(t *testing.T, s pet.Snapshot) {
+		if s.Type != "Dog" || s.Name != "Balto" || s.Breed != "Husky" {
+			t.Errorf("Expected Balto the Husky Dog, got %s the %s %s", s.Name, s.Breed, s.Type)
+		}
+		if len(s.Traits) != 0 {
+			t.Errorf("Pets from before traits should have none, got %v", s.Traits)
+		}
+	},
+	9: func(t *testing.T, s pet.Snapshot) {
+		if s.Type != "Bird" || s.Name != "Kiwi" || s.Breed != "Parrot" {
+			t.Errorf("Expected Kiwi the Parrot Bird, got %s the %s %s", s.Name, s.Breed, s.Type)
+		}
+		if len(s.Traits) != 2 || s.Traits[0] != "Glutton" || s.Traits[1] != "Anxious" {
+			t.Errorf("Traits not kept: %v", s.Traits)
+		}
+		if s.Energy != 100 || s.AsleepSeconds != 0 {
+			t.Errorf("Pets from before energy should be rested and awake, got %d energy, %g seconds asleep", s.Energy, s.AsleepSeconds)
+		}
+	},
+	10: func(t *testing.T, s pet.Snapshot) {
+		if s.Type != "Cat" || s.Name != "Mochi" || s.Breed != "Siamese" {
+			t.Errorf("Expected Mochi the Siamese Cat, got %s the %s %s", s.Name, s.Breed, s.Type)
+		}
+		if s.Energy != 34 || s.AsleepSeconds != 22.5 || s.Simulation.EnergyRemainder != 0.6 {
+			t.Errorf("Sleep state not kept: %+v", s)
+		}
+		if s.Weight != 50 {
+			t.Errorf("Pets from before weight should be at the ideal weight, got %d", s.Weight)
+		}
+	},
+	11: func(t *testing.T, s pet.Snapshot) {
+		if s.Type != "Dog" || s.Name != "Biscuit" || s.Breed != "Beagle" {
+			t.Errorf("Expected Biscuit the Beagle Dog, got %s the %s %s", s.Name, s.Breed, s.Type)
+		}
+		if s.Weight != 71 || s.HeavySeconds != 84 || s.SatietySeconds != 12.5 || s.WeightTrendSeconds != 40 {
+			t.Errorf("Weight state not kept: %+v", s)
+		}
+		if s.Pantry["Kibble"] != 10 || s.Pantry["Treats"] != 5 {
+			t.Errorf("Pets from before the pantry should get the starter pantry, got %v", s.Pantry)
+		}
+	},
+	12: func(t *testing.T, s pet.Snapshot) {
+		if s.Type != "Cat" || s.Name != "Pepper" || s.Breed != "Persian" {
+			t.Errorf("Expected Pepper the Persian Cat, got %s the %s %s", s.Name, s.Breed, s.Type)
+		}
+		tuna, ok := s.Pantry["Tuna"]
+		if s.Pantry["Kibble"] != 4 || s.Pantry["Treats"] != 2 || !ok || tuna != 0 {
+			t.Errorf("Pantry not kept: %v", s.Pantry)
+		}
+		if s.Coins != 20 || s.Milestone != "" {
+			t.Errorf("Pets from before coins should get the starter coins, got %d and milestone %q", s.Coins, s.Milestone)
+		}
+	},
+	13: func(t *testing.T, s pet.Snapshot) {
+		if s.Type != "Bird" || s.Name != "Rio" || s.Breed != "Parrot" {
+			t.Errorf("Expected Rio the Parrot Bird, got %s the %s %s", s.Name, s.Breed, s.Type)
+		}
+		if s.Supplies["Ball"] != 1 || s.WellSeconds != 12.5 || s.Milestone != pet.Adult {
+			t.Errorf("Coins and supplies not kept: %+v", s)
+		}
+		if _, ok := s.Supplies["Medicine"]; ok || s.Coins != 37+2*15 {
+			t.Errorf("Medicine from before the illness catalogue should be refunded, got %d coins and %v", s.Coins, s.Supplies)
+		}
+	},
+	14: func(t *testing.T, s pet.Snapshot) {
+		if s.Type != "Dog" || s.Name != "Scout" || s.Breed != "Beagle" {
+			t.Errorf("Expected Scout the Beagle Dog, got %s the %s %s", s.Name, s.Breed, s.Type)
+		}
+		if !s.IsIll || s.IllnessName != "Fever" || s.SickSeconds != 50 || s.Supplies["Fever Reducer"] != 1 {
+			t.Errorf("Illness not kept: %+v", s)
+		}
+	},
+	15: func(t *testing.T, s pet.Snapshot) {
+		if s.Type != "Cat" || s.Name != "Nori" || s.Breed != "Siamese" {
+			t.Errorf("Expected Nori the Siamese Cat, got %s the %s %s", s.Name, s.Breed, s.Type)
+		}
+		if s.AsleepSeconds != 30 || s.NapHealth != 30 || s.Health != 55 {
+			t.Errorf("Nap not kept: %+v", s)
+		}
+	},
+}
+
+func TestEveryVersionHasAFixture(t *testing.T) {
+	for version := 1; version <= CurrentVersion; version++ {
+		path := filepath.Join("testdata", fmt.Sprintf("v%d.json", version))
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Missing fixture for save version %d: %v", version, err)
+		}
+		if fixtureChecks[version] == nil {
+			t.Errorf("Missing fixture check for save version %d", version)
+		}
+	}
+}
+
+func TestFixturesLoad(t *testing.T) {
+	for version, check := range fixtureChecks {
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			file, err := Read(filepath.Join("testdata", fmt.Sprintf("v%d.json", version)))
+			if err != nil {
+				t.Fatalf("Read failed: %v", err)
+			}
+			if file.Version != CurrentVersion {
+				t.Errorf("Expected version %d after migration, got %d", CurrentVersion, file.Version)
+			}
+			if _, err := pet.Restore(file.Pet); err != nil {
+				t.Errorf("Restore failed: %v", err)
+			}
+			check(t, file.Pet)
+		})
+	}
+}
+
+func TestNewerVersionIsRefused(t *testing.T) {
+	_, err := Read(filepath.Join("testdata", "too_new.json"))
+	if !errors.Is(err, ErrTooNew) {
+		t.Errorf("Expected ErrTooNew, got %v", err)
+	}
+}
+
+func TestSaveWritesCurrentVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := Save(path, pet.NewBird("Tweety", "Canary"), time.Now()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	file, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if file.Version != CurrentVersion {
+		t.Errorf("Expected version %d, got %d", CurrentVersion, file.Version)
+	}
+}
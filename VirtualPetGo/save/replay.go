@@ -0,0 +1,132 @@
+package save
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/pet"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+)
+
+var (
+	ErrNoCheckpoint = errors.New("journal has no checkpoint to start from")
+	ErrDiverged     = errors.New("replaying the journal did not give the recorded result")
+)
+
+// Replay rebuilds the pet from the first checkpoint in the journal, applying every
+// entry after it and checking that each has the effect that was recorded.
+// The returned file is what a save at the time of the last entry would hold.
+func Replay(entries []Entry) (*File, error) {
+	for i, entry := range entries {
+		if entry.Kind == EntryCheckpoint {
+			return replay(entries[i:])
+		}
+	}
+	return nil, ErrNoCheckpoint
+}
+
+// Recover rebuilds the pet from the last checkpoint in the journal, the last
+// good point, and replays only what happened after it
+func Recover(entries []Entry) (*File, error) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Kind == EntryCheckpoint {
+			return replay(entries[i:])
+		}
+	}
+	return nil, ErrNoCheckpoint
+}
+
+// replay applies entries to the pet of the checkpoint they start with
+func replay(entries []Entry) (*File, error) {
+	var (
+		p        pet.Pet
+		clk      *clock.Manual
+		rng      *rand.Rand
+		verified = true
+	)
+
+	for _, entry := range entries {
+		verified = verified && entry.Verified
+		diverged := func(what string) error {
+			return fmt.Errorf("%w: entry %d (%s) %s", ErrDiverged, entry.Seq, entry.Kind, what)
+		}
+
+		if entry.Kind == EntryCheckpoint {
+			if entry.Checkpoint == nil {
+				return nil, diverged("has no checkpoint")
+			}
+			// A later checkpoint must match where the replay got to
+			if p != nil && !sameStats(p.Snapshot(), entry.Checkpoint.Pet) {
+				return nil, diverged("does not match the replayed pet")
+			}
+
+			source := &rand.PCG{}
+			if err := source.UnmarshalBinary(entry.Checkpoint.Rand); err != nil {
+				return nil, diverged("has no usable random state: " + err.Error())
+			}
+			rng = rand.New(source)
+			clk = clock.NewManual(entry.At)
+
+			var err error
+			if p, err = pet.Restore(entry.Checkpoint.Pet, pet.WithClock(clk), pet.WithRand(rng)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		before := p.GetStatus()
+		switch entry.Kind {
+		case EntryUpdate:
+			clk.Set(entry.At)
+			p.Update(entry.Elapsed)
+
+		case EntryAction:
+			clk.Set(entry.At)
+			pet.PerformWith(p, entry.Action, entry.Item)
+
+		case EntryOffline:
+			pet.SimulateOffline(p, clk, entry.Away, entry.Limit)
+
+			// The game then moves the pet to its own clock through a snapshot
+			clk.Set(entry.At)
+			var err error
+			if p, err = pet.Restore(p.Snapshot(), pet.WithClock(clk), pet.WithRand(rng)); err != nil {
+				return nil, err
+			}
+
+		case EntryTimeScale:
+			clk.Set(entry.At)
+			p.SetTimeScale(entry.Scale)
+
+		default:
+			return nil, diverged("has an unknown kind")
+		}
+		p.TakeEvents()
+
+		if !sameDelta(StatDelta(before, p.GetStatus()), entry.Delta) {
+			return nil, diverged("changed the stats differently")
+		}
+	}
+
+	return &File{
+		Version:  CurrentVersion,
+		SavedAt:  entries[len(entries)-1].At,
+		Pet:      p.Snapshot(),
+		Verified: verified,
+	}, nil
+}
+
+// sameStats compares what a checkpoint has to agree on with the replayed pet
+func sameStats(a, b pet.Snapshot) bool {
+	return a.Type == b.Type && a.Name == b.Name &&
+		a.Health == b.Health && a.Hunger == b.Hunger &&
+		a.Happiness == b.Happiness && a.Cleanliness == b.Cleanliness &&
+		a.IsIll == b.IsIll && a.IllnessName == b.IllnessName
+}
+
+func sameDelta(a, b *Delta) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
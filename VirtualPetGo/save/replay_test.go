@@ -0,0 +1,141 @@
+package save
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/pet"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// recorder plays a pet the way the game does and journals every step
+type recorder struct {
+	t       *testing.T
+	journal *Journal
+	pet     pet.Pet
+	clk     *clock.Manual
+	source  *rand.PCG
+	last    time.Time
+}
+
+func newRecorder(t *testing.T, path string, seed uint64) *recorder {
+	journal, err := OpenJournal(path)
+	if err != nil {
+		t.Fatalf("OpenJournal failed: %v", err)
+	}
+	t.Cleanup(func() { journal.Close() })
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	r := &recorder{t: t, journal: journal, clk: clock.NewManual(start), source: rand.NewPCG(seed, seed), last: start}
+	r.pet = pet.NewCat("Whiskers", "Orange", pet.WithClock(r.clk), pet.WithRand(rand.New(r.source)))
+	r.checkpoint()
+	return r
+}
+
+func (r *recorder) append(entry Entry) {
+	if err := r.journal.Append(entry); err != nil {
+		r.t.Fatalf("Append failed: %v", err)
+	}
+}
+
+func (r *recorder) update(d time.Duration) {
+	r.clk.Advance(d)
+	now := r.clk.Now()
+	before := r.pet.GetStatus()
+	elapsed := now.Sub(r.last).Seconds()
+	r.pet.Update(elapsed)
+	r.last = now
+	r.append(Entry{At: now, Kind: EntryUpdate, Elapsed: elapsed, Delta: StatDelta(before, r.pet.GetStatus())})
+}
+
+func (r *recorder) act(action pet.Action) {
+	before := r.pet.GetStatus()
+	pet.Perform(r.pet, action)
+	r.append(Entry{At: r.clk.Now(), Kind: EntryAction, Action: action, Delta: StatDelta(before, r.pet.GetStatus())})
+}
+
+func (r *recorder) checkpoint() {
+	state, _ := r.source.MarshalBinary()
+	r.append(Entry{At: r.clk.Now(), Kind: EntryCheckpoint, Checkpoint: &Checkpoint{Pet: r.pet.Snapshot(), Rand: state}})
+}
+
+// play runs long enough for illness rolls, a revive and every action
+func (r *recorder) play() {
+	for i := 0; i < 40; i++ {
+		r.update(1700 * time.Millisecond)
+	}
+	r.act(pet.ActionFeed)
+	r.act(pet.ActionAbility)
+	r.checkpoint()
+	for i := 0; i < 60; i++ {
+		r.update(2300 * time.Millisecond)
+		if i%20 == 0 {
+			r.act(pet.ActionPlay)
+		}
+	}
+	r.act(pet.ActionClean)
+	r.update(time.Second)
+}
+
+func snapshotJSON(s pet.Snapshot) string {
+	s.BirthTime = s.BirthTime.UTC()
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+func TestReplayRebuildsThePet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pet.journal")
+	r := newRecorder(t, path, 7)
+	r.play()
+
+	entries, err := ReadJournal(path)
+	if err != nil {
+		t.Fatalf("ReadJournal failed: %v", err)
+	}
+
+	for name, rebuild := range map[string]func([]Entry) (*File, error){"Replay": Replay, "Recover": Recover} {
+		file, err := rebuild(entries)
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		if got, want := snapshotJSON(file.Pet), snapshotJSON(r.pet.Snapshot()); got != want {
+			t.Errorf("%s gave a different pet:\nwant %s\ngot  %s", name, want, got)
+		}
+		if !file.Verified {
+			t.Errorf("%s of an untouched journal should verify", name)
+		}
+		if !file.SavedAt.Equal(r.clk.Now()) {
+			t.Errorf("%s should end at the last entry, got %v", name, file.SavedAt)
+		}
+	}
+}
+
+func TestReplayDetectsDivergence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pet.journal")
+	r := newRecorder(t, path, 7)
+	r.play()
+
+	entries, _ := ReadJournal(path)
+	for i := range entries {
+		if entries[i].Kind == EntryUpdate && entries[i].Delta != nil {
+			entries[i].Delta.Hunger -= 10
+			break
+		}
+	}
+	if _, err := Replay(entries); !errors.Is(err, ErrDiverged) {
+		t.Errorf("Expected ErrDiverged, got %v", err)
+	}
+}
+
+func TestReplayNeedsACheckpoint(t *testing.T) {
+	entries := []Entry{{Seq: 1, At: time.Now(), Kind: EntryUpdate, Elapsed: 1}}
+	if _, err := Replay(entries); !errors.Is(err, ErrNoCheckpoint) {
+		t.Errorf("Expected ErrNoCheckpoint, got %v", err)
+	}
+	if _, err := Recover(entries); !errors.Is(err, ErrNoCheckpoint) {
+		t.Errorf("Expected ErrNoCheckpoint, got %v", err)
+	}
+}
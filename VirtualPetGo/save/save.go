@@ -0,0 +1,114 @@
+package save
+
+import (
+	"VirtualPetGo/pet"
+	"fmt"
+	"os"
+	"time"
+)
+
+// File is the on-disk layout of a save
+type File struct {
+	Version int          `json:"version"` // See CurrentVersion
+	SavedAt time.Time    `json:"saved_at"`
+	Pet     pet.Snapshot `json:"pet"`
+
+	// HMAC of the rest of the file, see sign.go
+	Signature string `json:"signature,omitempty"`
+
+	// Verified is set by Decode when the signature matches,
+	// false for unsigned, edited and shared format saves
+	Verified bool `json:"-"`
+
+	// Legacy is set by Decode for saves that were never signed: saves from
+	// before signing (version 1 and 2) and shared format saves. Only those
+	// already in a save folder when the game was upgraded are trusted, see
+	// Slots.SignLegacy, any other one counts as edited.
+	Legacy bool `json:"-"`
+
+	// Recovered is set when the pet was rebuilt from the slot's journal
+	// because the save was missing or older than the journal
+	Recovered bool `json:"-"`
+}
+
+// Save writes the pet to path as signed JSON, savedAt is recorded for offline progression.
+// The file is replaced atomically, a crash while saving keeps the previous save.
+func Save(path string, p pet.Pet, savedAt time.Time) error {
+	return SaveSnapshot(path, p.Snapshot(), savedAt)
+}
+
+// SaveSnapshot is Save for a pet already copied to a snapshot
+func SaveSnapshot(path string, s pet.Snapshot, savedAt time.Time) error {
+	file := File{
+		Version: CurrentVersion,
+		SavedAt: savedAt,
+		Pet:     s,
+	}
+
+	data, err := sign(file)
+	if err != nil {
+		return fmt.Errorf("encoding save: %w", err)
+	}
+
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("writing save: %w", err)
+	}
+	return nil
+}
+
+// Read loads the raw save file without rebuilding the pet,
+// older save versions are upgraded on the way in
+func Read(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading save: %w", err)
+	}
+
+	file, err := Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding save %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// Edited reports whether the save may have been changed outside the game:
+// its signature doesn't match or it has none
+func (f *File) Edited() bool {
+	return !f.Verified
+}
+
+// resign writes a legacy save back to path as a signed save of the current
+// version, so it verifies from now on
+func resign(path string, file *File) error {
+	file.Version = CurrentVersion
+	file.Signature = ""
+	data, err := sign(*file)
+	if err != nil {
+		return fmt.Errorf("encoding save: %w", err)
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("writing save: %w", err)
+	}
+	file.Verified = true
+	file.Legacy = false
+	return nil
+}
+
+// Load reads a save file and rebuilds the pet stored in it.
+// A pet from a save that fails the signature check is marked as modified.
+func Load(path string) (pet.Pet, error) {
+	file, err := Read(path)
+	if err != nil {
+		return nil, err
+	}
+	if file.Edited() {
+		file.Pet.Modified = true
+	}
+	return pet.Restore(file.Pet)
+}
+
+// Exists reports whether there is a save file at path
+func Exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
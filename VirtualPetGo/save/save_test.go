@@ -0,0 +1,48 @@
+package save
+
+import (
+	"VirtualPetGo/pet"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	dog := pet.NewDog("Max", "Golden Retriever")
+	dog.UseSpecialAbility()
+	dog.Feed()
+
+	cat := pet.NewCat("Whiskers", "Orange")
+	cat.UseSpecialAbility()
+
+	bird := pet.NewBird("Tweety", "Canary")
+	bird.UseSpecialAbility()
+
+	for _, original := range []pet.Pet{dog, cat, bird} {
+		path := filepath.Join(t.TempDir(), "save.json")
+
+		if err := Save(path, original, time.Now()); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+
+		loaded, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+
+		// Compare the encoded form so time zones and monotonic readings don't matter
+		want, _ := json.Marshal(original.Snapshot())
+		got, _ := json.Marshal(loaded.Snapshot())
+		if string(got) != string(want) {
+			t.Errorf("Pet changed on round trip:\nwant %s\ngot  %s", want, got)
+		}
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err == nil {
+		t.Error("Loading a missing file should fail")
+	}
+}
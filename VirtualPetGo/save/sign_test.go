@@ -0,0 +1,209 @@
+package save
+
+import (
+	"VirtualPetGo/interop"
+	"VirtualPetGo/pet"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// editSave changes the pet's hunger in a save file the way a player would by hand
+func editSave(t *testing.T, path string, hunger int) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	doc["pet"].(map[string]any)["hunger"] = hunger
+	data, _ = json.MarshalIndent(doc, "", "  ")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSavedFilesVerify(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := Save(path, pet.NewDog("Max", "Golden Retriever"), time.Now()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	file, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if file.Signature == "" || !file.Verified {
+		t.Error("A save written by the game should verify")
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.GetStatus().Modified {
+		t.Error("An untouched save should not be marked as modified")
+	}
+}
+
+func TestEditedSaveIsMarkedModified(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	hungry := pet.NewCat("Whiskers", "Orange")
+	hungry.Update(30)
+	if err := Save(path, hungry, time.Now()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	editSave(t, path, 100)
+
+	file, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if file.Verified {
+		t.Error("An edited save should not verify")
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !loaded.GetStatus().Modified {
+		t.Error("A pet from an edited save should be marked as modified")
+	}
+
+	// Saving again signs the file but the pet stays marked
+	if err := Save(path, loaded, time.Now()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !reloaded.GetStatus().Modified {
+		t.Error("The modified mark should survive saving again")
+	}
+}
+
+func TestReformattedSaveStillVerifies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := Save(path, pet.NewBird("Tweety", "Canary"), time.Now()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Whitespace and key order are not part of the signature
+	data, _ := os.ReadFile(path)
+	var doc document
+	json.Unmarshal(data, &doc)
+	compact, _ := json.Marshal(doc)
+	os.WriteFile(path, compact, 0644)
+
+	file, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if !file.Verified {
+		t.Error("Reformatting a save should not break its signature")
+	}
+}
+
+func TestOnlySignedFixturesVerify(t *testing.T) {
+	for version := 1; version <= CurrentVersion; version++ {
+		file, err := Read(filepath.Join("testdata", fmt.Sprintf("v%d.json", version)))
+		if err != nil {
+			t.Fatalf("Read v%d failed: %v", version, err)
+		}
+		// Signatures arrived in version 3, older saves are legacy saves
+		if want := version >= 3; file.Verified != want || file.Legacy == want || file.Edited() == want {
+			t.Errorf("v%d: expected verified %v, got verified %v legacy %v", version, want, file.Verified, file.Legacy)
+		}
+	}
+}
+
+// legacySaves are a save from before signing and a shared save
+func legacySaves(t *testing.T) map[string][]byte {
+	t.Helper()
+	old, err := os.ReadFile(filepath.Join("testdata", "v2.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	shared, err := interop.Encode(pet.NewCat("Whiskers", "Orange").Snapshot(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string][]byte{"v2": old, "shared": shared}
+}
+
+func TestLegacySavesOnDiskAreSignedOnce(t *testing.T) {
+	slots := NewSlots(t.TempDir())
+	for name, data := range legacySaves(t) {
+		os.MkdirAll(slots.dir, 0755)
+		os.WriteFile(slots.Path(name), data, 0644)
+	}
+
+	if err := slots.SignLegacy(); err != nil {
+		t.Fatalf("SignLegacy failed: %v", err)
+	}
+	for name := range legacySaves(t) {
+		file, err := slots.Read(name)
+		if err != nil {
+			t.Fatalf("%s: Read failed: %v", name, err)
+		}
+		if !file.Verified || file.Version != CurrentVersion {
+			t.Errorf("%s: expected the save to be signed, got version %d verified %v", name, file.Version, file.Verified)
+		}
+	}
+}
+
+func TestLegacySavesAfterTheUpgradeAreModified(t *testing.T) {
+	slots := NewSlots(t.TempDir())
+	if err := slots.SignLegacy(); err != nil {
+		t.Fatalf("SignLegacy failed: %v", err)
+	}
+
+	// Stripping the signature and the version of a save, or sharing it,
+	// doesn't get an edit trusted once the folder was upgraded
+	for name, data := range legacySaves(t) {
+		os.WriteFile(slots.Path(name), data, 0644)
+		if err := slots.SignLegacy(); err != nil {
+			t.Fatalf("SignLegacy failed: %v", err)
+		}
+
+		loaded, err := Load(slots.Path(name))
+		if err != nil {
+			t.Fatalf("%s: Load failed: %v", name, err)
+		}
+		if !loaded.GetStatus().Modified {
+			t.Errorf("%s: a legacy save written after the upgrade should be marked as modified", name)
+		}
+		if file, _ := Read(slots.Path(name)); file.Verified {
+			t.Errorf("%s: a legacy save should not be signed on load", name)
+		}
+	}
+}
+
+func TestSignedVersionWithoutSignatureIsModified(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := Save(path, pet.NewDog("Max", "Golden Retriever"), time.Now()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	var doc document
+	json.Unmarshal(data, &doc)
+	delete(doc, "signature")
+	data, _ = json.Marshal(doc)
+	os.WriteFile(path, data, 0644)
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !loaded.GetStatus().Modified {
+		t.Error("A current save without a signature should be marked as modified")
+	}
+}
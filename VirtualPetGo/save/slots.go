@@ -0,0 +1,342 @@
+package save
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/interop"
+	"VirtualPetGo/pet"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultDir is where the game keeps its save slots
+const DefaultDir = "saves"
+
+const (
+	slotExt    = ".json"
+	journalExt = ".journal"
+	// legacyMark is written once the saves from before signing were signed,
+	// see SignLegacy
+	legacyMark = ".legacy-signed"
+)
+
+var (
+	ErrSlotExists  = errors.New("a slot with that name already exists")
+	ErrBadSlotName = errors.New("a slot name can't be blank or contain a path")
+)
+
+// checkName refuses slot names that would not make a file of their own in the folder
+func checkName(name string) error {
+	if strings.TrimSpace(name) == "" || strings.HasPrefix(name, ".") || filepath.Base(name) != name {
+		return ErrBadSlotName
+	}
+	return nil
+}
+
+// SlotInfo is what the slot screen shows about a save without loading it
+type SlotInfo struct {
+	Name       string // Slot name, the file name without extension
+	PetName    string
+	Species    string
+	AgeStage   pet.AgeStage // At the time of the last save
+	LastPlayed time.Time
+	IsAlive    bool
+	Modified   bool  // The save was edited outside the game or the pet is marked as modified
+	Paused     bool  // Saved while paused
+	Err        error // Set if the slot could not be read
+}
+
+// Slots manages named save files in one directory
+type Slots struct {
+	dir string
+}
+
+func NewSlots(dir string) *Slots {
+	return &Slots{dir: dir}
+}
+
+// Path returns the file a slot is stored in
+func (s *Slots) Path(name string) string {
+	return filepath.Join(s.dir, name+slotExt)
+}
+
+// JournalPath returns the file a slot's journal is kept in
+func (s *Slots) JournalPath(name string) string {
+	return filepath.Join(s.dir, name+journalExt)
+}
+
+// Exists reports whether a slot is in use, by a save or a journal
+func (s *Slots) Exists(name string) bool {
+	return Exists(s.Path(name)) || Exists(s.JournalPath(name))
+}
+
+// List returns every slot sorted by name
+func (s *Slots) List() ([]SlotInfo, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing slots: %w", err)
+	}
+
+	// A slot whose save is lost can still be recovered from its journal
+	var slots []SlotInfo
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name, ext := entry.Name(), filepath.Ext(entry.Name())
+		if ext != slotExt && ext != journalExt {
+			continue
+		}
+		name = strings.TrimSuffix(name, ext)
+		if !seen[name] {
+			seen[name] = true
+			slots = append(slots, s.info(name))
+		}
+	}
+
+	sort.Slice(slots, func(i, j int) bool { return slots[i].Name < slots[j].Name })
+	return slots, nil
+}
+
+// info reads the metadata of one slot
+func (s *Slots) info(name string) SlotInfo {
+	info := SlotInfo{Name: name}
+
+	file, err := s.peek(name)
+	if err != nil {
+		info.Err = err
+		return info
+	}
+
+	// Rebuild the pet on a clock stopped at the save time to get its age stage then
+	saved, err := pet.Restore(file.Pet, pet.WithClock(clock.NewManual(file.SavedAt)))
+	if err != nil {
+		info.Err = err
+		return info
+	}
+	status := saved.GetStatus()
+
+	info.PetName = status.Name
+	info.Species = status.Type
+	info.AgeStage = status.AgeStage
+	info.LastPlayed = file.SavedAt
+	info.IsAlive = status.IsAlive
+	info.Modified = file.Edited() || status.Modified
+	info.Paused = status.Paused
+	return info
+}
+
+// Save writes the pet to a slot, creating it if needed
+func (s *Slots) Save(name string, p pet.Pet, savedAt time.Time) error {
+	return s.SaveSnapshot(name, p.Snapshot(), savedAt)
+}
+
+// SaveSnapshot writes a pet already copied to a snapshot to a slot
+func (s *Slots) SaveSnapshot(name string, snapshot pet.Snapshot, savedAt time.Time) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("creating save folder: %w", err)
+	}
+	return SaveSnapshot(s.Path(name), snapshot, savedAt)
+}
+
+// SignLegacy signs the legacy saves in the folder the first time this version
+// of the game runs, as they were saved by a game that didn't sign. After that
+// the folder is marked and a legacy save showing up in it is an edited one,
+// anyone can strip a signature or write a shared save by hand.
+func (s *Slots) SignLegacy() error {
+	mark := filepath.Join(s.dir, legacyMark)
+	if Exists(mark) {
+		return nil
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("creating save folder: %w", err)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*"+slotExt))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		file, err := Read(path)
+		if err != nil || !file.Legacy {
+			continue
+		}
+		if err := resign(path, file); err != nil {
+			return err
+		}
+	}
+	return writeFileAtomic(mark, nil)
+}
+
+// OpenJournal opens the slot's journal for appending, creating it if needed
+func (s *Slots) OpenJournal(name string) (*Journal, error) {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating save folder: %w", err)
+	}
+	return OpenJournal(s.JournalPath(name))
+}
+
+// Read loads the raw save file of a slot. If the save is missing, unreadable or
+// older than the slot's journal, the pet is recovered from the journal instead.
+func (s *Slots) Read(name string) (*File, error) {
+	file, err := Read(s.Path(name))
+
+	entries, journalErr := ReadJournal(s.JournalPath(name))
+	if journalErr != nil || len(entries) == 0 {
+		return file, err
+	}
+	if err == nil && !entries[len(entries)-1].At.After(file.SavedAt) {
+		return file, nil
+	}
+
+	recovered, recoverErr := Recover(entries)
+	if recoverErr != nil {
+		if err != nil {
+			return nil, err
+		}
+		// The journal is no use, the save is still good
+		return file, nil
+	}
+	recovered.Recovered = true
+	return recovered, nil
+}
+
+// peek reads a slot for the slot screen without replaying its journal: the save,
+// or the journal's last checkpoint when the save can't be read
+func (s *Slots) peek(name string) (*File, error) {
+	file, err := Read(s.Path(name))
+	if err == nil {
+		return file, nil
+	}
+
+	entries, journalErr := ReadJournal(s.JournalPath(name))
+	if journalErr != nil {
+		return nil, err
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entry := entries[i]; entry.Kind == EntryCheckpoint && entry.Checkpoint != nil {
+			return &File{
+				Version:   CurrentVersion,
+				SavedAt:   entry.At,
+				Pet:       entry.Checkpoint.Pet,
+				Verified:  entry.Verified,
+				Recovered: true,
+			}, nil
+		}
+	}
+	return nil, err
+}
+
+// Rename moves a slot to a new name
+func (s *Slots) Rename(name, newName string) error {
+	if err := checkName(newName); err != nil {
+		return err
+	}
+	if s.Exists(newName) {
+		return ErrSlotExists
+	}
+	for _, path := range [][2]string{
+		{s.Path(name), s.Path(newName)},
+		{s.JournalPath(name), s.JournalPath(newName)},
+	} {
+		err := os.Rename(path[0], path[1])
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("renaming slot: %w", err)
+		}
+	}
+	return nil
+}
+
+// Duplicate copies a slot under a new name
+func (s *Slots) Duplicate(name, newName string) error {
+	if err := checkName(newName); err != nil {
+		return err
+	}
+	if s.Exists(newName) {
+		return ErrSlotExists
+	}
+	for _, path := range [][2]string{
+		{s.Path(name), s.Path(newName)},
+		{s.JournalPath(name), s.JournalPath(newName)},
+	} {
+		data, err := os.ReadFile(path[0])
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("reading slot: %w", err)
+		}
+		if err := writeFileAtomic(path[1], data); err != nil {
+			return fmt.Errorf("writing slot: %w", err)
+		}
+	}
+	return nil
+}
+
+// Export writes a slot to path in the format shared with the C# game
+func (s *Slots) Export(name, path string) error {
+	file, err := s.Read(name)
+	if err != nil {
+		return err
+	}
+	data, err := interop.Encode(file.Pet, file.SavedAt)
+	if err != nil {
+		return fmt.Errorf("encoding shared save: %w", err)
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("writing shared save: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a slot
+func (s *Slots) Delete(name string) error {
+	for _, path := range []string{s.Path(name), s.JournalPath(name)} {
+		err := os.Remove(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("deleting slot: %w", err)
+		}
+	}
+	return nil
+}
+
+// FreeName returns base, or base with a number added if that slot is taken
+func (s *Slots) FreeName(base string) string {
+	name := base
+	for i := 2; s.Exists(name); i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	return name
+}
+
+// Files returns the content of every file in the save folder by name
+func (s *Slots) Files() (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return files, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing save folder: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading save folder: %w", err)
+		}
+		files[entry.Name()] = data
+	}
+	return files, nil
+}
@@ -0,0 +1,169 @@
+package save
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/pet"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSlotsListShowsMetadata(t *testing.T) {
+	slots := NewSlots(t.TempDir())
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clk := clock.NewManual(start)
+
+	// An adult cat saved 7 minutes after birth
+	cat := pet.NewCat("Whiskers", "Orange", pet.WithClock(clk))
+	clk.Advance(7 * time.Minute)
+	if err := slots.Save("Home", cat, clk.Now()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	list, err := slots.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 slot, got %d", len(list))
+	}
+
+	info := list[0]
+	if info.Name != "Home" || info.PetName != "Whiskers" || info.Species != "Cat" {
+		t.Errorf("Unexpected slot info: %+v", info)
+	}
+	if info.AgeStage != pet.Adult {
+		t.Errorf("Expected Adult at the time of saving, got %s", info.AgeStage)
+	}
+	if !info.LastPlayed.Equal(clk.Now()) {
+		t.Errorf("Expected last played %v, got %v", clk.Now(), info.LastPlayed)
+	}
+	if !info.IsAlive {
+		t.Error("Slot should show the cat is alive")
+	}
+}
+
+func TestSlotsListEmptyDir(t *testing.T) {
+	slots := NewSlots(t.TempDir() + "/missing")
+
+	list, err := slots.List()
+	if err != nil {
+		t.Fatalf("Listing a missing folder should not fail: %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("Expected no slots, got %d", len(list))
+	}
+}
+
+func TestSlotsRenameDuplicateDelete(t *testing.T) {
+	slots := NewSlots(t.TempDir())
+	if err := slots.Save("First", pet.NewDog("Max", "Golden Retriever"), time.Now()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	if err := slots.Rename("First", "Second"); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+	if slots.Exists("First") || !slots.Exists("Second") {
+		t.Error("Rename should move the slot")
+	}
+
+	if err := slots.Duplicate("Second", "Third"); err != nil {
+		t.Fatalf("Duplicate failed: %v", err)
+	}
+	if !slots.Exists("Second") || !slots.Exists("Third") {
+		t.Error("Duplicate should keep both slots")
+	}
+
+	if err := slots.Rename("Second", "Third"); !errors.Is(err, ErrSlotExists) {
+		t.Errorf("Renaming onto an existing slot should fail with ErrSlotExists, got %v", err)
+	}
+
+	for _, name := range []string{"", "  ", "../Escaped", ".hidden"} {
+		if err := slots.Rename("Second", name); !errors.Is(err, ErrBadSlotName) {
+			t.Errorf("Renaming to %q should fail with ErrBadSlotName, got %v", name, err)
+		}
+		if err := slots.Duplicate("Second", name); !errors.Is(err, ErrBadSlotName) {
+			t.Errorf("Duplicating to %q should fail with ErrBadSlotName, got %v", name, err)
+		}
+	}
+
+	if err := slots.Delete("Second"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if slots.Exists("Second") {
+		t.Error("Delete should remove the slot")
+	}
+}
+
+func TestSlotsFreeName(t *testing.T) {
+	slots := NewSlots(t.TempDir())
+
+	if name := slots.FreeName("Max"); name != "Max" {
+		t.Errorf("Expected 'Max', got '%s'", name)
+	}
+
+	slots.Save("Max", pet.NewDog("Max", "Golden Retriever"), time.Now())
+	if name := slots.FreeName("Max"); name != "Max2" {
+		t.Errorf("Expected 'Max2', got '%s'", name)
+	}
+}
+
+func TestSlotsExportCanBeReadBack(t *testing.T) {
+	dir := t.TempDir()
+	slots := NewSlots(dir)
+	savedAt := time.Now()
+	if err := slots.Save("Mine", pet.NewBird("Tweety", "Canary"), savedAt); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Dropping the exported file into the save folder makes it a slot
+	if err := slots.Export("Mine", filepath.Join(dir, "Shared.json")); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	file, err := slots.Read("Shared")
+	if err != nil {
+		t.Fatalf("Reading the shared save failed: %v", err)
+	}
+	if file.Pet.Type != "Bird" || file.Pet.Name != "Tweety" {
+		t.Errorf("Expected Bird Tweety, got %s %s", file.Pet.Type, file.Pet.Name)
+	}
+	if !file.SavedAt.Equal(savedAt) {
+		t.Errorf("Expected save time %v, got %v", savedAt, file.SavedAt)
+	}
+}
+
+func TestSlotsReadRecoversFromJournal(t *testing.T) {
+	slots := NewSlots(t.TempDir())
+	r := newRecorder(t, slots.JournalPath("Cat"), 1)
+	if err := slots.Save("Cat", r.pet, r.clk.Now()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// The game kept going but crashed before saving again
+	for i := 0; i < 10; i++ {
+		r.update(1500 * time.Millisecond)
+	}
+	r.act(pet.ActionPlay)
+
+	file, err := slots.Read("Cat")
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if !file.Recovered {
+		t.Error("A save older than its journal should be recovered from the journal")
+	}
+	if got, want := snapshotJSON(file.Pet), snapshotJSON(r.pet.Snapshot()); got != want {
+		t.Errorf("Recovered pet differs:\nwant %s\ngot  %s", want, got)
+	}
+
+	// Losing the save altogether still leaves the slot
+	os.Remove(slots.Path("Cat"))
+	list, _ := slots.List()
+	if len(list) != 1 || list[0].PetName != "Whiskers" {
+		t.Errorf("Expected the journal to keep the slot listed, got %+v", list)
+	}
+}
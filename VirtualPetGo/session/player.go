@@ -0,0 +1,150 @@
+package session
+
+import (
+	"VirtualPetGo/clock"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Player hands a recorded session back to the game one event at a time.
+// As soon as the game asks for something the recording doesn't have next,
+// the player stops: Done is closed and Err tells why.
+type Player struct {
+	mu     sync.Mutex
+	events []Event
+	pos    int
+	last   time.Time // Last clock reading handed out
+	err    error
+	done   chan struct{}
+}
+
+func NewPlayer(log *Log) *Player {
+	return &Player{events: log.Events, done: make(chan struct{})}
+}
+
+// Clock returns a clock that replays the recorded readings in order
+func (p *Player) Clock() clock.Clock {
+	return playerClock{p}
+}
+
+// Input returns the next recorded input line, or io.EOF where the input ended.
+// It returns ErrEnded when the game wants input the recording doesn't have,
+// which is how a recording normally ends.
+func (p *Player) Input() (string, error) {
+	p.mu.Lock()
+	atEnd := p.pos >= len(p.events) || p.events[p.pos].Kind == KindShutdown
+	if atEnd && p.err == nil {
+		// The game was waiting for input when the recording ended
+		p.stop(nil)
+		p.mu.Unlock()
+		return "", ErrEnded
+	}
+	p.mu.Unlock()
+
+	event, ok := p.next(KindInput)
+	switch {
+	case !ok:
+		return "", ErrEnded
+	case event.EOF:
+		return "", io.EOF
+	}
+	return event.Line, nil
+}
+
+// Shutdown reports whether the recording continues with the game being stopped
+// by a signal, and if so moves past that event
+func (p *Player) Shutdown() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil || p.pos >= len(p.events) || p.events[p.pos].Kind != KindShutdown {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+// Background returns the background event recorded next, if the next event is one
+func (p *Player) Background() (Kind, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil || p.pos >= len(p.events) {
+		return "", false
+	}
+	kind := p.events[p.pos].Kind
+	if kind != KindTick && kind != KindAutosave {
+		return "", false
+	}
+	p.pos++
+	return kind, true
+}
+
+// Done is closed once the player has stopped
+func (p *Player) Done() <-chan struct{} {
+	return p.done
+}
+
+// Err is nil if the player stopped where the recording waited for input,
+// or wraps ErrDiverged if the game asked for something else than was recorded
+func (p *Player) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+// Finished reports whether every recorded event was played
+func (p *Player) Finished() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err == nil && p.pos == len(p.events)
+}
+
+// next takes the next event if it is of the wanted kind, otherwise stops the player
+func (p *Player) next(want Kind) (Event, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err != nil {
+		return Event{}, false
+	}
+	if p.pos >= len(p.events) {
+		p.stop(fmt.Errorf("%w: the recording ended, the game asked for %s", ErrDiverged, want))
+		return Event{}, false
+	}
+	event := p.events[p.pos]
+	if event.Kind != want {
+		p.stop(fmt.Errorf("%w: event %d is %s, the game asked for %s", ErrDiverged, p.pos+1, event.Kind, want))
+		return Event{}, false
+	}
+	p.pos++
+	return event, true
+}
+
+// stop closes done once, callers must hold p.mu
+func (p *Player) stop(err error) {
+	select {
+	case <-p.done:
+	default:
+		p.err = err
+		close(p.done)
+	}
+}
+
+type playerClock struct {
+	player *Player
+}
+
+// Now returns the next recorded reading. Once the player has stopped
+// it keeps returning the last one so the game can't run ahead.
+func (c playerClock) Now() time.Time {
+	if event, ok := c.player.next(KindClock); ok {
+		c.player.mu.Lock()
+		c.player.last = event.At
+		c.player.mu.Unlock()
+		return event.At
+	}
+	c.player.mu.Lock()
+	defer c.player.mu.Unlock()
+	return c.player.last
+}
@@ -0,0 +1,196 @@
+// Package session records everything a game session depends on so it can be
+// played back exactly: the seed, the save folder, every clock reading,
+// every input line and every background event.
+package session
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/pet"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Kind says what a session event is
+type Kind string
+
+const (
+	KindStart    Kind = "start"    // First event, see Start
+	KindClock    Kind = "clock"    // The game read the clock
+	KindInput    Kind = "input"    // The game read a line of input
+	KindTick     Kind = "tick"     // A background update ran
+	KindAutosave Kind = "autosave" // A background autosave ran
+	KindShutdown Kind = "shutdown" // The game was stopped by a signal
+	KindEnd      Kind = "end"      // Last event, see End
+)
+
+var (
+	ErrDiverged = errors.New("replay went differently from the recording")
+	ErrEnded    = errors.New("the recording has ended")
+)
+
+// Event is one line of a session log
+type Event struct {
+	Kind  Kind      `json:"kind"`
+	At    time.Time `json:"at,omitzero"` // Clock: the reading
+	Line  string    `json:"line,omitempty"`
+	EOF   bool      `json:"eof,omitempty"` // Input: the input ended instead
+	Start *Start    `json:"start,omitempty"`
+	End   *End      `json:"end,omitempty"`
+}
+
+// Start is what the session started from
+type Start struct {
+	Seed             uint64            `json:"seed"`
+	MaxOffline       time.Duration     `json:"max_offline"`
+	TrustEditedSaves bool              `json:"trust_edited_saves"`
+	TimeScale        float64           `json:"time_scale,omitempty"`
+	Files            map[string][]byte `json:"files"` // The save folder by file name
+}
+
+// End is how the session finished
+type End struct {
+	Shutdown bool        `json:"shutdown"` // Stopped by a signal rather than from the menu
+	Status   *pet.Status `json:"status"`   // nil if no pet was created
+}
+
+// Recorder writes a session log as the game runs
+type Recorder struct {
+	mu     sync.Mutex
+	file   *os.File
+	err    error // First write error, later events are dropped
+	closed bool
+}
+
+// Create starts a new session log at path
+func Create(path string) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating session log: %w", err)
+	}
+	return &Recorder{file: file}, nil
+}
+
+// Start records what the session starts from, it must be the first event
+func (r *Recorder) Start(start Start) {
+	r.write(Event{Kind: KindStart, Start: &start})
+}
+
+// Clock returns inner with every reading recorded
+func (r *Recorder) Clock(inner clock.Clock) clock.Clock {
+	return recordingClock{inner: inner, recorder: r}
+}
+
+// Input records a line the game read
+func (r *Recorder) Input(line string) {
+	r.write(Event{Kind: KindInput, Line: line})
+}
+
+// EndOfInput records that the game found the input had ended
+func (r *Recorder) EndOfInput() {
+	r.write(Event{Kind: KindInput, EOF: true})
+}
+
+// Background records a background event as it starts
+func (r *Recorder) Background(kind Kind) {
+	r.write(Event{Kind: kind})
+}
+
+// End records how the session finished and closes the log
+func (r *Recorder) End(end End) error {
+	r.write(Event{Kind: KindEnd, End: &end})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.closed {
+		r.closed = true
+		if err := r.file.Close(); err != nil && r.err == nil {
+			r.err = err
+		}
+	}
+	return r.err
+}
+
+// Err returns the first error writing the log
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *Recorder) write(event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed || r.err != nil {
+		return
+	}
+
+	line, err := json.Marshal(event)
+	if err == nil {
+		_, err = r.file.Write(append(line, '\n'))
+	}
+	if err != nil {
+		r.err = fmt.Errorf("writing session log: %w", err)
+	}
+}
+
+type recordingClock struct {
+	inner    clock.Clock
+	recorder *Recorder
+}
+
+func (c recordingClock) Now() time.Time {
+	// Without the monotonic reading the game sees exactly what is recorded
+	now := c.inner.Now().Round(0)
+	c.recorder.write(Event{Kind: KindClock, At: now})
+	return now
+}
+
+// Log is a session log read back
+type Log struct {
+	Start  Start
+	Events []Event // Everything between Start and End
+	End    *End    // nil if the recording was cut short
+}
+
+// Read loads a session log
+func Read(path string) (*Log, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading session log: %w", err)
+	}
+	defer file.Close()
+
+	log := &Log{}
+	scanner := bufio.NewScanner(file)
+	// The start event carries the whole save folder
+	scanner.Buffer(nil, 256<<20)
+	for n := 1; scanner.Scan(); n++ {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("session log line %d: %w", n, err)
+		}
+
+		switch {
+		case n == 1 && (event.Kind != KindStart || event.Start == nil):
+			return nil, fmt.Errorf("session log doesn't begin with a start event")
+		case n == 1:
+			log.Start = *event.Start
+		case event.Kind == KindEnd:
+			log.End = event.End
+		default:
+			log.Events = append(log.Events, event)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading session log: %w", err)
+	}
+	if log.Start.Files == nil {
+		return nil, fmt.Errorf("session log has no start event")
+	}
+	return log, nil
+}
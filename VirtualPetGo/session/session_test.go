@@ -0,0 +1,101 @@
+package session
+
+import (
+	"VirtualPetGo/clock"
+	"VirtualPetGo/pet"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// recordSample writes a short session: two clock readings, a tick, input and the end
+func recordSample(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "session.log")
+	rec, err := Create(path)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	rec.Start(Start{Seed: 42, Files: map[string][]byte{"Max.json": []byte("{}")}})
+	clk := rec.Clock(clock.NewManual(start))
+	clk.Now()
+	rec.Background(KindTick)
+	rec.Input("1")
+	clk.Now()
+	rec.EndOfInput()
+	if err := rec.End(End{Status: &pet.Status{Name: "Max", Hunger: 80}}); err != nil {
+		t.Fatalf("End failed: %v", err)
+	}
+	return path
+}
+
+func TestRecordAndRead(t *testing.T) {
+	log, err := Read(recordSample(t))
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+
+	if log.Start.Seed != 42 || string(log.Start.Files["Max.json"]) != "{}" {
+		t.Errorf("Start not kept: %+v", log.Start)
+	}
+	if len(log.Events) != 5 {
+		t.Fatalf("Expected 5 events, got %d", len(log.Events))
+	}
+	if log.End == nil || log.End.Status == nil || log.End.Status.Hunger != 80 {
+		t.Errorf("End not kept: %+v", log.End)
+	}
+}
+
+func TestPlayerHandsBackTheRecording(t *testing.T) {
+	log, _ := Read(recordSample(t))
+	player := NewPlayer(log)
+	clk := player.Clock()
+
+	if now := clk.Now(); !now.Equal(start) {
+		t.Errorf("Expected the recorded reading %v, got %v", start, now)
+	}
+	if kind, ok := player.Background(); !ok || kind != KindTick {
+		t.Errorf("Expected the recorded tick, got %q", kind)
+	}
+	if _, ok := player.Background(); ok {
+		t.Error("Only one background event was recorded")
+	}
+	if line, err := player.Input(); err != nil || line != "1" {
+		t.Errorf("Expected input 1, got %q, %v", line, err)
+	}
+	clk.Now()
+	if _, err := player.Input(); !errors.Is(err, io.EOF) {
+		t.Errorf("Expected the recorded end of input, got %v", err)
+	}
+
+	// Asking for more input than was recorded is the normal end
+	if _, err := player.Input(); !errors.Is(err, ErrEnded) {
+		t.Errorf("Expected ErrEnded, got %v", err)
+	}
+	select {
+	case <-player.Done():
+	default:
+		t.Error("Player should be done")
+	}
+	if player.Err() != nil || !player.Finished() {
+		t.Errorf("Player should have finished cleanly, got %v", player.Err())
+	}
+}
+
+func TestPlayerStopsWhenTheGameDiverges(t *testing.T) {
+	log, _ := Read(recordSample(t))
+	player := NewPlayer(log)
+
+	// The recording starts with a clock reading, not input
+	if _, err := player.Input(); !errors.Is(err, ErrEnded) {
+		t.Errorf("Expected ErrEnded, got %v", err)
+	}
+	if !errors.Is(player.Err(), ErrDiverged) {
+		t.Errorf("Expected ErrDiverged, got %v", player.Err())
+	}
+}
@@ -2,20 +2,32 @@ package ui
 
 import (
 	"VirtualPetGo/pet"
+	"VirtualPetGo/save"
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
+	"time"
 )
 
 // IUserInterface defines what a UI implementation must provide
 type IUserInterface interface {
 	DisplayWelcome()
-	DisplayMainMenu()
+	DisplayMainMenu(pet.Pet)
 	DisplayStatus(pet.Pet)
 	DisplayMessage(string)
 	ClearScreen()
-	DisplayPetSelection()
+	DisplayPetSelection(species []pet.Descriptor)
+	DisplayBreedSelection(species string, breeds []pet.Breed)
 	DisplayWarnings(pet.Pet)
+	DisplaySlotManager([]save.SlotInfo)
+	DisplayOfflineReport(pet.OfflineReport)
+	DisplayAlert(string)
+	DisplaySettings(timeScale float64)
+	DisplayPantry(pet.Pet)
+	DisplayShop(p pet.Pet, items []pet.ShopItem)
+	DisplayMiniGame(pet.Pet)
+	DisplayMedicine(p pet.Pet, medicines []pet.ShopItem)
 }
 type ConsoleUI struct{}
 
@@ -23,20 +35,37 @@ func NewConsoleUI() *ConsoleUI {
 	return &ConsoleUI{}
 }
 
-// DisplayMainMenu shows the main menu options
-func (cui *ConsoleUI) DisplayMainMenu() {
+// petActions are the pet's actions in menu order, from choice 1
+var petActions = []pet.Action{
+	pet.ActionFeed,
+	pet.ActionPlay,
+	pet.ActionSleep,
+	pet.ActionClean,
+	pet.ActionInteract,
+	pet.ActionAbility,
+}
+
+// DisplayMainMenu shows the main menu options, named for the pet's species
+func (cui *ConsoleUI) DisplayMainMenu(p pet.Pet) {
 	fmt.Println("\n────────────────────────────────────────────────")
 	fmt.Println("╔════════════════════════════════════════════╗")
 	fmt.Println("║              WHAT WILL YOU DO?             ║")
 	fmt.Println("╚════════════════════════════════════════════╝")
-	fmt.Println("1. Feed")
-	fmt.Println("2. Play")
-	fmt.Println("3. Sleep")
-	fmt.Println("4. Clean")
-	fmt.Println("5. Interact (Make Sound)")
-	fmt.Println("6. Use Special Ability")
+	for i, action := range petActions {
+		if p.Supports(action) {
+			fmt.Printf("%d. %s\n", i+1, p.ActionLabel(action))
+		} else {
+			fmt.Printf("%d. %s (not for a %s)\n", i+1, p.ActionLabel(action), p.GetStatus().Type)
+		}
+	}
 	fmt.Println("7. View Status")
-	fmt.Println("8. Exit Game")
+	fmt.Println("8. Save Game")
+	fmt.Println("9. Exit Game")
+	fmt.Println("10. Pause / Resume")
+	fmt.Println("11. Shop")
+	fmt.Printf("12. %s\n", p.ActionLabel(pet.ActionMiniGame))
+	fmt.Printf("13. %s\n", p.ActionLabel(pet.ActionMedicine))
+	fmt.Println("0. Settings")
 	fmt.Print("\nChoose an action: ")
 }
 
@@ -51,18 +80,53 @@ func (cui *ConsoleUI) DisplayStatus(p pet.Pet) {
 
 	// Header
 	fmt.Printf("\n=== %s's Status ===\n", status.Name)
-	fmt.Printf("Type: %s\n", status.Type)
+	if status.Breed != "" {
+		fmt.Printf("Type: %s (%s)\n", status.Type, status.Breed)
+	} else {
+		fmt.Printf("Type: %s\n", status.Type)
+	}
 	fmt.Printf("Age: %.2f Years (%s)\n", status.Age, status.AgeStage)
+	if status.Traits != "" {
+		fmt.Printf("Traits: %s\n", status.Traits)
+	}
+	if status.TimeScale != 1 {
+		fmt.Printf("Time: %gx\n", status.TimeScale)
+	}
 
 	// Stats with progress bars
 	fmt.Printf("Health:      %d/100 [%s]\n", status.Health, makeProgressBar(status.Health))
 	fmt.Printf("Hunger:      %d/100 [%s]\n", status.Hunger, makeProgressBar(status.Hunger))
 	fmt.Printf("Happiness:   %d/100 [%s]\n", status.Happiness, makeProgressBar(status.Happiness))
-	fmt.Printf("Cleanliness: %d/100 [%s]\n", status.Cleanliness, makeProgressBar(status.Cleanliness))
+	if status.Tank {
+		fmt.Printf("Water:       %d/100 [%s]\n", status.Cleanliness, makeProgressBar(status.Cleanliness))
+	} else {
+		fmt.Printf("Cleanliness: %d/100 [%s]\n", status.Cleanliness, makeProgressBar(status.Cleanliness))
+	}
+	fmt.Printf("Energy:      %d/100 [%s]\n", status.Energy, makeProgressBar(status.Energy))
+	fmt.Printf("Weight:      %d (%s)\n", status.Weight, weightTrendText(status.WeightTrend))
+	fmt.Printf("Coins:       %d\n", status.Coins)
+	if supplies := suppliesText(p.Supplies()); supplies != "" {
+		fmt.Printf("Supplies:    %s\n", supplies)
+	}
+	if status.Overweight {
+		fmt.Printf("\n⚖️  %s is overweight, play and sleep do less for them\n", status.Name)
+	}
+	switch status.Sleep {
+	case pet.Asleep:
+		fmt.Printf("\n💤 %s is asleep, caring for them will wake them up\n", status.Name)
+	case pet.Groggy:
+		fmt.Printf("\n🥱 %s is groggy after being woken up\n", status.Name)
+	}
 
 	// Illness status
-	if status.IsIll {
-		fmt.Printf("\n🤒 ILLNESS: %s is sick with %s!\n", status.Name, status.IllnessName)
+	if status.IsIll && status.IllnessName == "" {
+		// Saves from the C# game don't name the illness
+		fmt.Printf("\n🤒 ILLNESS: %s is sick!\n", status.Name)
+	} else if status.IsIll {
+		fmt.Printf("\n🤒 ILLNESS: %s is sick with %s (%s)!\n", status.Name, status.IllnessName, status.IllnessStage)
+		if illness, ok := pet.IllnessNamed(status.IllnessName); ok {
+			fmt.Printf("   %s. Cured by %s\n", illness.Description, curesText(illness, status.Tank))
+		}
 	}
 
 	// Special ability
@@ -70,6 +134,14 @@ func (cui *ConsoleUI) DisplayStatus(p pet.Pet) {
 
 	// Overall status
 	fmt.Printf("Status: %s\n", status.StatusMessage)
+	if status.Paused {
+		fmt.Println("⏸️  PAUSED: time stands still until you resume")
+	}
+	fmt.Printf("Played: %s, paused: %s\n",
+		status.ActiveTime.Round(time.Second), status.PausedTime.Round(time.Second))
+	if status.Modified {
+		fmt.Println("⚠️ Modified: this pet was loaded from a save edited outside the game")
+	}
 	fmt.Println("===================")
 }
 
@@ -104,15 +176,113 @@ func (cui *ConsoleUI) DisplayMessage(message string) {
 	fmt.Println(message)
 }
 
-// DisplayPetSelection shows the pet type selection menu
-func (cui *ConsoleUI) DisplayPetSelection() {
+// DisplayPetSelection shows the pet type selection menu, one entry per registered species
+func (cui *ConsoleUI) DisplayPetSelection(species []pet.Descriptor) {
 	fmt.Println("\n╔════════════════════════════════════════════╗")
 	fmt.Println("║          CHOOSE YOUR PET TYPE              ║")
 	fmt.Println("╚════════════════════════════════════════════╝")
-	fmt.Println("1. Dog   - Loyal companion with happiness boost")
-	fmt.Println("2. Cat   - Independent pet with 9 lives")
-	fmt.Println("3. Bird  - Cheerful singer with stat boosts")
-	fmt.Print("\nSelect pet type (1-3): ")
+	for i, d := range species {
+		fmt.Printf("%d. %-5s - %s\n", i+1, d.Name, d.Blurb)
+		fmt.Printf("   Ability: %s\n", d.Ability)
+	}
+	fmt.Printf("\nSelect pet type (1-%d): ", len(species))
+}
+
+// DisplayBreedSelection shows the breeds of the chosen species
+func (cui *ConsoleUI) DisplayBreedSelection(species string, breeds []pet.Breed) {
+	fmt.Printf("\nChoose a %s breed:\n", species)
+	for i, b := range breeds {
+		fmt.Printf("%d. %-16s - %s\n", i+1, b.Name, b.Blurb)
+	}
+	fmt.Printf("\nSelect breed (1-%d): ", len(breeds))
+}
+
+// DisplaySlotManager lists the save slots and what can be done with them
+func (cui *ConsoleUI) DisplaySlotManager(slots []save.SlotInfo) {
+	fmt.Println("\n╔════════════════════════════════════════════╗")
+	fmt.Println("║                 SAVE SLOTS                 ║")
+	fmt.Println("╚════════════════════════════════════════════╝")
+	for i, slot := range slots {
+		if slot.Err != nil {
+			fmt.Printf("%d. %-12s (unreadable: %v)\n", i+1, slot.Name, slot.Err)
+			continue
+		}
+
+		state := "Alive"
+		if !slot.IsAlive {
+			state = "Dead"
+		}
+		if slot.Modified {
+			state += ", modified"
+		}
+		if slot.Paused {
+			state += ", paused"
+		}
+		fmt.Printf("%d. %-12s %s the %s, %s, %s, last played %s\n",
+			i+1, slot.Name, slot.PetName, slot.Species, slot.AgeStage, state,
+			slot.LastPlayed.Local().Format("2006-01-02 15:04"))
+	}
+
+	fmt.Println("────────────────────────────────────────────────")
+	fmt.Println("1. New pet")
+	fmt.Println("2. Load slot")
+	fmt.Println("3. Rename slot")
+	fmt.Println("4. Duplicate slot")
+	fmt.Println("5. Delete slot")
+	fmt.Println("6. Export slot for the C# game")
+	fmt.Print("\nChoose an option (1-6): ")
+}
+
+// DisplayOfflineReport shows what happened while the game was closed
+func (cui *ConsoleUI) DisplayOfflineReport(report pet.OfflineReport) {
+	before, after := report.Before, report.After
+
+	fmt.Println("\n╔════════════════════════════════════════════╗")
+	fmt.Println("║           WHILE YOU WERE AWAY...           ║")
+	fmt.Println("╚════════════════════════════════════════════╝")
+	fmt.Printf("You were gone for %s.\n", report.Away.Round(time.Second))
+	if report.Simulated < report.Away {
+		fmt.Printf("(Only the first %s were simulated.)\n", report.Simulated.Round(time.Second))
+	}
+
+	fmt.Println()
+	printStatChange("Health", before.Health, after.Health)
+	printStatChange("Hunger", before.Hunger, after.Hunger)
+	printStatChange("Happiness", before.Happiness, after.Happiness)
+	if after.Tank {
+		printStatChange("Water", before.Cleanliness, after.Cleanliness)
+	} else {
+		printStatChange("Cleanliness", before.Cleanliness, after.Cleanliness)
+	}
+	printStatChange("Energy", before.Energy, after.Energy)
+	printStatChange("Weight", before.Weight, after.Weight)
+	printStatChange("Coins", before.Coins, after.Coins)
+
+	for _, illness := range report.Illnesses {
+		fmt.Printf("\n🤒 %s caught %s.\n", after.Name, illness)
+	}
+	if report.LivesUsed > 0 {
+		fmt.Printf("\n🐱 %s used %d of their lives.\n", after.Name, report.LivesUsed)
+	}
+	if report.Died {
+		fmt.Printf("\n💀 %s died while you were away...\n", after.Name)
+	}
+}
+
+// weightTrendText shows the weight trend with an arrow
+func weightTrendText(trend pet.WeightTrend) string {
+	switch trend {
+	case pet.Gaining:
+		return "↑ gaining"
+	case pet.Losing:
+		return "↓ losing"
+	}
+	return "→ steady"
+}
+
+// printStatChange prints one line of the away report
+func printStatChange(label string, before, after int) {
+	fmt.Printf("%-12s %3d -> %3d (%+d)\n", label+":", before, after, after-before)
 }
 
 func (cui *ConsoleUI) ClearScreen() {
@@ -128,35 +298,122 @@ func (cui *ConsoleUI) DisplayWarnings(p pet.Pet) {
 		return
 	}
 
-	status := p.GetStatus()
-	hasWarning := false
+	warnings := p.GetStatus().Warnings()
+	for _, warning := range warnings {
+		fmt.Printf("\n⚠️  %s\n", warning)
+	}
 
-	if !status.IsAlive {
-		fmt.Println("\n⚠️  WARNING: Your pet's health is critical! ⚠️")
-		hasWarning = true
-	} else {
-		if status.Hunger < 30 {
-			fmt.Printf("\n⚠️  %s is very hungry!\n", status.Name)
-			hasWarning = true
-		}
+	if len(warnings) > 0 {
+		fmt.Println()
+	}
+}
 
-		if status.Happiness < 30 {
-			fmt.Printf("\n⚠️  %s is feeling sad!\n", status.Name)
-			hasWarning = true
-		}
+// DisplayAlert shows something that happened while waiting for input
+func (cui *ConsoleUI) DisplayAlert(message string) {
+	fmt.Printf("\n%s\n", message)
+}
+
+// DisplaySettings shows the settings and asks for a new time scale
+func (cui *ConsoleUI) DisplaySettings(timeScale float64) {
+	fmt.Println("\n╔════════════════════════════════════════════╗")
+	fmt.Println("║                  SETTINGS                  ║")
+	fmt.Println("╚════════════════════════════════════════════╝")
+	fmt.Printf("Time scale: %gx\n", timeScale)
+	fmt.Println("Decay, aging, illness and ability timers all run at this speed,")
+	fmt.Printf("e.g. %gx for a relaxed pet, 1x for real time, %gx for demos.\n", pet.MinTimeScale, pet.MaxTimeScale)
+	fmt.Printf("\nEnter a new time scale (%g-%g): ", pet.MinTimeScale, pet.MaxTimeScale)
+}
+
+// DisplayPantry lists the food left and asks which one to feed
+func (cui *ConsoleUI) DisplayPantry(p pet.Pet) {
+	name := p.GetStatus().Name
+	pantry := p.Pantry()
 
-		if status.Health < 30 {
-			fmt.Printf("\n⚠️  %s's health is low!\n", status.Name)
-			hasWarning = true
+	fmt.Printf("\nWhat will you feed %s?\n", name)
+	for i, food := range pet.Foods() {
+		fmt.Printf("%d. %-7s x%-3d - %s (+%d hunger, +%d happiness)%s\n", i+1, food.Name,
+			pantry[food.Name], food.Description, food.Nutrition, food.Happiness, tasteText(pet.TasteOf(p, food.Name)))
+	}
+	fmt.Println("0. Back")
+	fmt.Printf("\nSelect food (0-%d): ", len(pet.Foods()))
+}
+
+// tasteText marks the foods a pet loves or won't eat
+func tasteText(taste pet.Taste) string {
+	switch taste {
+	case pet.Loves:
+		return " ♥ loves it"
+	case pet.Refuses:
+		return " ✗ won't eat it"
+	}
+	return ""
+}
+
+// DisplayShop lists what the shop sells and asks what to buy
+func (cui *ConsoleUI) DisplayShop(p pet.Pet, items []pet.ShopItem) {
+	fmt.Println("\n╔════════════════════════════════════════════╗")
+	fmt.Println("║                    SHOP                    ║")
+	fmt.Println("╚════════════════════════════════════════════╝")
+	fmt.Printf("You have %d coins.\n\n", p.Coins())
+	for i, item := range items {
+		name := item.Name
+		if item.Quantity > 1 {
+			name = fmt.Sprintf("%d %s", item.Quantity, item.Name)
 		}
+		fmt.Printf("%d. %-16s %3d coins - %s\n", i+1, name, item.Price, item.Description)
+	}
+	fmt.Println("0. Leave")
+	fmt.Printf("\nBuy (0-%d): ", len(items))
+}
 
-		if status.Cleanliness < 30 {
-			fmt.Printf("\n⚠️  %s is getting dirty!\n", status.Name)
-			hasWarning = true
+// DisplayMiniGame explains Find the Treat and asks for a cup
+func (cui *ConsoleUI) DisplayMiniGame(p pet.Pet) {
+	fmt.Printf("\n%s hides a treat under one of %d cups and shuffles them...\n", p.GetStatus().Name, pet.MiniGameCups)
+	fmt.Printf("Find it to win %d coins.\n", pet.MiniGameCoins)
+	fmt.Printf("\nWhich cup (1-%d, 0 to stop)? ", pet.MiniGameCups)
+}
+
+// DisplayMedicine lists the medicines and how many are left
+func (cui *ConsoleUI) DisplayMedicine(p pet.Pet, medicines []pet.ShopItem) {
+	supplies := p.Supplies()
+	fmt.Printf("\nWhich medicine will you give %s?\n", p.GetStatus().Name)
+	for i, item := range medicines {
+		fmt.Printf("%d. %-14s x%-3d - %s\n", i+1, item.Name, supplies[item.Name], item.Description)
+	}
+	fmt.Println("0. Back")
+	fmt.Printf("\nSelect medicine (0-%d): ", len(medicines))
+}
+
+// suppliesText lists the supplies the pet has, in shop order
+func suppliesText(supplies map[string]int) string {
+	var list []string
+	for _, item := range pet.Shop() {
+		switch count := supplies[item.Name]; {
+		case count == 1:
+			list = append(list, item.Name)
+		case count > 1:
+			list = append(list, fmt.Sprintf("%s x%d", item.Name, count))
 		}
 	}
+	return strings.Join(list, ", ")
+}
 
-	if hasWarning {
-		fmt.Println()
+// curesText lists what cures an illness
+func curesText(illness pet.Illness, tank bool) string {
+	var cures []string
+	if illness.Medicine != "" {
+		cures = append(cures, illness.Medicine)
+	}
+	if illness.Rest {
+		cures = append(cures, "a full sleep")
+	}
+	if illness.Clean && tank {
+		cures = append(cures, "a water change")
+	} else if illness.Clean {
+		cures = append(cures, "a good clean")
+	}
+	if illness.Passes > 0 {
+		cures = append(cures, fmt.Sprintf("waiting %gs for it to pass", illness.Passes))
 	}
+	return strings.Join(cures, ", or ")
 }
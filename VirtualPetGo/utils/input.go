@@ -3,17 +3,40 @@ package utils
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
 	"unicode"
 )
 
-func ReadInt() (int, error) {
-	scanner := bufio.NewScanner(os.Stdin)
+// ReadLine is where every read function takes its input from, one line at a time.
+// It reads standard input unless replaced, to record or replay a session.
+// It returns io.EOF when the input has ended.
+var ReadLine = LinesFrom(os.Stdin)
+
+// LinesFrom reads lines from r. All reads share one scanner,
+// so lines buffered ahead of time aren't lost between reads.
+func LinesFrom(r io.Reader) func() (string, error) {
+	scanner := bufio.NewScanner(r)
+	return func() (string, error) {
+		if scanner.Scan() {
+			return scanner.Text(), nil
+		}
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+}
 
-	for scanner.Scan() {
-		input := strings.TrimSpace(scanner.Text())
+func ReadInt() (int, error) {
+	for {
+		line, err := ReadLine()
+		if err != nil {
+			return 0, err
+		}
+		input := strings.TrimSpace(line)
 
 		value, err := strconv.Atoi(input)
 		if err != nil {
@@ -23,16 +46,16 @@ func ReadInt() (int, error) {
 
 		return value, nil
 	}
-
-	return 0, scanner.Err()
 }
 
 // ReadString reads a string containing only letters from standard input
 func ReadString() (string, error) {
-	scanner := bufio.NewScanner(os.Stdin)
-
-	for scanner.Scan() {
-		input := strings.TrimSpace(scanner.Text())
+	for {
+		line, err := ReadLine()
+		if err != nil {
+			return "", err
+		}
+		input := strings.TrimSpace(line)
 
 		if input == "" {
 			fmt.Print("Input cannot be empty. Please try again: ")
@@ -46,13 +69,10 @@ func ReadString() (string, error) {
 
 		return input, nil
 	}
-
-	return "", scanner.Err()
 }
 func WaitForEnter() {
 	fmt.Println("Press enter to continue...")
-	var dummy string
-	fmt.Scanln(&dummy)
+	ReadLine()
 }
 
 // ReadIntInRange reads an integer within a specific range
@@ -72,6 +92,45 @@ func ReadIntInRange(min, max int) (int, error) {
 	}
 }
 
+// ReadFloatInRange reads a decimal number within a specific range
+func ReadFloatInRange(min, max float64) (float64, error) {
+	for {
+		line, err := ReadLine()
+		if err != nil {
+			return 0, err
+		}
+
+		value, err := strconv.ParseFloat(strings.TrimSpace(line), 64)
+		if err != nil {
+			fmt.Print("Invalid input. Please enter a number: ")
+			continue
+		}
+		if value < min || value > max {
+			fmt.Printf("Please enter a number between %g and %g: ", min, max)
+			continue
+		}
+
+		return value, nil
+	}
+}
+
+// ReadYesNo reads a y/n answer
+func ReadYesNo() (bool, error) {
+	for {
+		line, err := ReadLine()
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Print("Please answer y or n: ")
+	}
+}
+
 // isLettersOnly checks if a string contains only letters
 func isLettersOnly(s string) bool {
 	for _, char := range s {